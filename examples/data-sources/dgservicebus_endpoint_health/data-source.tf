@@ -0,0 +1,11 @@
+data "dgservicebus_endpoint_health" "example" {
+  endpoint_name = "example-endpoint"
+  topic_name    = "example-topic"
+}
+
+check "endpoint_dead_letter_queue" {
+  assert {
+    condition     = data.dgservicebus_endpoint_health.example.queue.dead_letter_message_count == 0
+    error_message = "The dead-letter queue of example-endpoint is not empty."
+  }
+}
@@ -0,0 +1,8 @@
+data "dgservicebus_endpoints" "example" {
+  topic_name  = "bundle-1"
+  name_prefix = "sales-"
+}
+
+output "sales_endpoint_names" {
+  value = [for endpoint in data.dgservicebus_endpoints.example.endpoints : endpoint.endpoint_name]
+}
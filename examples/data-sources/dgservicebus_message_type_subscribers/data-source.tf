@@ -0,0 +1,11 @@
+data "dgservicebus_message_type_subscribers" "example" {
+  message_type = "Dg.SalesOrder.V1.SalesOrderCreated"
+  topic_names  = ["bundle-1"]
+}
+
+check "sales_order_created_has_subscribers" {
+  assert {
+    condition     = length(data.dgservicebus_message_type_subscribers.example.subscribers) > 0
+    error_message = "Dg.SalesOrder.V1.SalesOrderCreated has no subscribers."
+  }
+}
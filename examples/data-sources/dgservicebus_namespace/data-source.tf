@@ -0,0 +1,9 @@
+data "dgservicebus_namespace" "example" {
+}
+
+check "namespace_entity_quota" {
+  assert {
+    condition     = data.dgservicebus_namespace.example.quotas.entity_usage_percentage < 80
+    error_message = "The namespace uses more than 80% of its entity quota."
+  }
+}
@@ -0,0 +1,17 @@
+data "dgservicebus_queues" "example" {
+  name_prefix                = "sales-"
+  include_runtime_properties = true
+}
+
+data "dgservicebus_endpoints" "example" {
+  topic_name  = "example-topic"
+  name_prefix = "sales-"
+}
+
+# Queues, which are no longer backed by an endpoint
+output "orphaned_queues" {
+  value = setsubtract(
+    data.dgservicebus_queues.example.queues[*].name,
+    data.dgservicebus_endpoints.example.endpoints[*].endpoint_name,
+  )
+}
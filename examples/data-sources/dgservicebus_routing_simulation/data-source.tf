@@ -0,0 +1,6 @@
+data "dgservicebus_routing_simulation" "example" {
+  topic_name = "bundle-1"
+  message_properties = {
+    "NServiceBus.EnclosedMessageTypes" = "Dg.SalesOrder.V1.SalesOrderCreated, Dg.SalesOrder, Version=1.0.0.0"
+  }
+}
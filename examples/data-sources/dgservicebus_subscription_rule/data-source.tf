@@ -0,0 +1,13 @@
+# Look up a rule by the message type it was created for
+data "dgservicebus_subscription_rule" "by_message_type" {
+  topic_name        = "example-topic"
+  subscription_name = "example-endpoint"
+  message_type      = "Dg.SalesOrder.V1.SalesOrderCreated"
+}
+
+# Look up a rule by its name
+data "dgservicebus_subscription_rule" "by_name" {
+  topic_name        = "example-topic"
+  subscription_name = "example-endpoint"
+  rule_name         = "Dg.SalesOrder.V1.SalesOrderCreated"
+}
@@ -0,0 +1,9 @@
+resource "azurerm_servicebus_subscription_rule" "example" {
+  name            = provider::dgservicebus::rule_name("Dg.SalesOrder.V1.SalesOrderCreated")
+  subscription_id = azurerm_servicebus_subscription.example.id
+  filter_type     = "CorrelationFilter"
+
+  correlation_filter {
+    properties = provider::dgservicebus::correlation_filter("Dg.SalesOrder.V1.SalesOrderCreated").properties
+  }
+}
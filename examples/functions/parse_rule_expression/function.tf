@@ -0,0 +1,9 @@
+data "dgservicebus_subscription_rule" "example" {
+  topic_name        = "example-topic"
+  subscription_name = "example-endpoint"
+  rule_name         = "Dg.SalesOrder.V1.SalesOrderCreated"
+}
+
+output "message_type" {
+  value = provider::dgservicebus::parse_rule_expression(data.dgservicebus_subscription_rule.example.sql_expression)
+}
@@ -0,0 +1,4 @@
+# Alert on the rule the endpoint resource creates for a subscription
+output "rule_name" {
+  value = provider::dgservicebus::rule_name("Dg.SalesOrder.V1.SalesOrderCreated")
+}
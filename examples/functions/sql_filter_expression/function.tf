@@ -0,0 +1,3 @@
+output "sql_filter_expression" {
+  value = provider::dgservicebus::sql_filter_expression("Dg.SalesOrder.V1.SalesOrderCreated")
+}
@@ -4,6 +4,23 @@ import (
 	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 )
 
+// Rule operations run sequentially by default, as Service Bus rejects concurrent operations on a subscription with 400 or 409.
+const DEFAULT_MAX_CONCURRENT_RULE_OPERATIONS = 1
+
 type AsbClientWrapper struct {
-	Client *az.Client
+	Client                      *az.Client
+	Hostname                    string            // The hostname of the namespace of Client, used for forwarding addresses
+	MaxConcurrentRuleOperations int               // The number of rules created or deleted in parallel, DEFAULT_MAX_CONCURRENT_RULE_OPERATIONS when not set
+	Cache                       *NamespaceCache   // Shared by all resources and data sources of the provider, caching is disabled when not set
+	Retry                       RetryOptions      // Applied to every call to Service Bus
+	RateLimiter                 *RateLimiter      // Shared by all resources and data sources of the provider, calls are not limited when not set
+	Namespaces                  *NamespaceClients // The clients for the namespace attribute of resources and data sources, overrides fail when not set
+}
+
+func (w *AsbClientWrapper) ruleOperationConcurrency() int {
+	if w.MaxConcurrentRuleOperations < 1 {
+		return DEFAULT_MAX_CONCURRENT_RULE_OPERATIONS
+	}
+
+	return w.MaxConcurrentRuleOperations
 }
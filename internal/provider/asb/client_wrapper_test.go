@@ -0,0 +1,32 @@
+package asb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a namespace, which is served by the handler. The SDK does not retry, such that
+// the retries of the client wrapper are tested.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *AsbClientWrapper {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	client, err := az.NewClientFromConnectionString(
+		"Endpoint=sb://"+host+"/;SharedAccessKeyName=Manage;SharedAccessKey=key",
+		&az.ClientOptions{ClientOptions: azcore.ClientOptions{
+			Transport: server.Client(),
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		}},
+	)
+	require.NoError(t, err)
+
+	return &AsbClientWrapper{Client: client, Hostname: host}
+}
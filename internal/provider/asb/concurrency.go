@@ -0,0 +1,62 @@
+package asb
+
+import (
+	"context"
+	"sync"
+)
+
+// runConcurrently runs the operation for every item with at most limit operations in parallel.
+// It does not stop at the first error, but returns the errors of all failed operations in the order of the items.
+func runConcurrently[T any](
+	ctx context.Context,
+	limit int,
+	items []T,
+	operation func(context.Context, T) error,
+) []error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	results := make([]error, len(items))
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			results[i] = operation(ctx, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+
+	errs := []error{}
+	for _, err := range results {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// RunRuleOperations runs the rule operations in parallel, bounded by MaxConcurrentRuleOperations,
+// and returns the errors of all failed operations.
+func (w *AsbClientWrapper) RunRuleOperations(
+	ctx context.Context,
+	operations []func(context.Context) error,
+) []error {
+	return runConcurrently(
+		ctx,
+		w.ruleOperationConcurrency(),
+		operations,
+		func(ctx context.Context, operation func(context.Context) error) error {
+			return operation(ctx)
+		},
+	)
+}
@@ -0,0 +1,52 @@
+package asb
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunConcurrently_RespectsLimit(t *testing.T) {
+	var running, maxRunning int32
+	items := make([]int, 20)
+
+	errs := runConcurrently(context.Background(), 3, items, func(_ context.Context, _ int) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	assert.Empty(t, errs)
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	assert.Greater(t, maxRunning, int32(1))
+}
+
+func TestRunConcurrently_CollectsAllErrors(t *testing.T) {
+	var calls int32
+	items := []int{0, 1, 2, 3, 4, 5}
+
+	errs := runConcurrently(context.Background(), 2, items, func(_ context.Context, item int) error {
+		atomic.AddInt32(&calls, 1)
+		if item%2 == 1 {
+			return fmt.Errorf("item %d failed", item)
+		}
+		return nil
+	})
+
+	assert.Equal(t, int32(len(items)), calls)
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "item 1 failed")
+	assert.EqualError(t, errs[2], "item 5 failed")
+}
@@ -23,6 +23,8 @@ func (w *AsbClientWrapper) CreateEndpointWithDefaultRuleWithOptinalForwading(
 	model AsbEndpointModel,
 	disableForward bool,
 ) error {
+	defer w.invalidateCachedSubscription(model.TopicName, model.EndpointName)
+
 	var queueNamePtr *string
 	if !disableForward {
 		queueName, err := w.GetFullyQualifiedName(azureContext, model.EndpointName)
@@ -34,49 +36,95 @@ func (w *AsbClientWrapper) CreateEndpointWithDefaultRuleWithOptinalForwading(
 		queueNamePtr = nil
 	}
 
-	_, err := w.Client.CreateSubscription(
+	return runWithRetryVoid(
 		azureContext,
-		model.TopicName,
-		model.EndpointName,
-		&az.CreateSubscriptionOptions{
-			Properties: &az.SubscriptionProperties{
-				ForwardTo:                        queueNamePtr,
-				MaxDeliveryCount:                 to.Ptr(MAX_DELIVERY_COUNT),
-				EnableBatchedOperations:          to.Ptr(true),
-				LockDuration:                     to.Ptr("PT5M"),
-				DeadLetteringOnMessageExpiration: to.Ptr(false),
-				EnableDeadLetteringOnFilterEvaluationExceptions: to.Ptr(false),
-				RequiresSession: to.Ptr(false),
-				DefaultRule: &az.RuleProperties{
-					Filter: &az.FalseFilter{},
-				},
-			},
-		})
-
-	return err
+		w.retryOptions(),
+		"Creating subscription "+model.EndpointName,
+		func() error {
+			_, err := w.Client.CreateSubscription(
+				azureContext,
+				model.TopicName,
+				model.EndpointName,
+				&az.CreateSubscriptionOptions{
+					Properties: &az.SubscriptionProperties{
+						ForwardTo:                        queueNamePtr,
+						MaxDeliveryCount:                 to.Ptr(MAX_DELIVERY_COUNT),
+						EnableBatchedOperations:          to.Ptr(true),
+						LockDuration:                     to.Ptr("PT5M"),
+						DeadLetteringOnMessageExpiration: to.Ptr(false),
+						EnableDeadLetteringOnFilterEvaluationExceptions: to.Ptr(false),
+						RequiresSession: to.Ptr(false),
+						DefaultRule: &az.RuleProperties{
+							Filter: &az.FalseFilter{},
+						},
+					},
+				})
+
+			return err
+		},
+	)
 }
 
 func (w *AsbClientWrapper) DeleteEndpoint(
 	azureContext context.Context,
 	model AsbEndpointModel,
 ) error {
-	_, err := w.Client.DeleteSubscription(
+	defer w.invalidateCachedSubscription(model.TopicName, model.EndpointName)
+
+	return runWithRetryVoid(
 		azureContext,
-		model.TopicName,
-		model.EndpointName,
-		nil,
-	)
+		w.retryOptions(),
+		"Deleting subscription "+model.EndpointName,
+		func() error {
+			_, err := w.Client.DeleteSubscription(
+				azureContext,
+				model.TopicName,
+				model.EndpointName,
+				nil,
+			)
 
-	return err
+			return err
+		},
+	)
 }
 
 func (w *AsbClientWrapper) EndpointExists(ctx context.Context, model AsbEndpointModel) (bool, error) {
-	subscription, err := w.Client.GetSubscription(ctx, model.TopicName, model.EndpointName, nil)
+	subscription, err := w.getCachedSubscription(ctx, model.TopicName, model.EndpointName)
 	if err != nil {
 		return false, err
 	}
-	if subscription == nil {
-		return false, nil
+	return subscription != nil, nil
+}
+
+func (w *AsbClientWrapper) GetEndpointSubscription(
+	ctx context.Context,
+	model AsbEndpointModel,
+) (*az.GetSubscriptionResponse, error) {
+	subscription, err := w.getCachedSubscription(ctx, model.TopicName, model.EndpointName)
+	if err != nil || subscription == nil {
+		return nil, err
+	}
+
+	return &az.GetSubscriptionResponse{
+		SubscriptionName:       model.EndpointName,
+		TopicName:              model.TopicName,
+		SubscriptionProperties: *subscription,
+	}, nil
+}
+
+func (w *AsbClientWrapper) GetEndpointSubscriptionRuleDetails(
+	ctx context.Context,
+	model AsbEndpointModel,
+) ([]AsbSubscriptionRuleDetails, error) {
+	rules, err := w.GetSubscriptionRules(ctx, model.TopicName, model.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]AsbSubscriptionRuleDetails, 0, len(rules))
+	for _, rule := range rules {
+		details = append(details, GetAsbSubscriptionRuleDetails(rule))
 	}
-	return true, nil
+
+	return details, nil
 }
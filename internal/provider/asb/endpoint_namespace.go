@@ -5,17 +5,10 @@ import (
 )
 
 func (w *AsbClientWrapper) GetFullyQualifiedName(ctx context.Context, entityName string) (string, error) {
-	return runWithRetryIncrementalBackOff(
-		ctx,
-		"Getting namespace properties",
-		func() (string, error) {
-			response, err := w.Client.GetNamespaceProperties(ctx, nil)
-			
-			if err != nil {
-				return "", err
-			}
+	namespace, err := w.GetFullyQualifiedNamespace(ctx)
+	if err != nil {
+		return "", err
+	}
 
-			return "sb://" + response.Name + ".servicebus.windows.net/" + entityName, nil
-		},
-	)
+	return namespace + entityName, nil
 }
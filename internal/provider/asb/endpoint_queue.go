@@ -12,8 +12,11 @@ func (w *AsbClientWrapper) CreateEndpointQueue(
 	queueName string,
 	queueOptions AsbEndpointQueueOptions,
 ) error {
-	return runWithRetryIncrementalBackOffVoid(
+	defer w.invalidateCachedQueue(queueName)
+
+	return runWithRetryVoid(
 		ctx,
+		w.retryOptions(),
 		"Creating queue"+queueName,
 		func() error {
 			_, err := w.Client.CreateQueue(
@@ -40,8 +43,12 @@ func (w *AsbClientWrapper) DeleteEndpointQueue(
 	ctx context.Context,
 	model AsbEndpointModel,
 ) error {
-	return runWithRetryIncrementalBackOffVoid(
-		ctx, "Deleting queue"+model.EndpointName,
+	defer w.invalidateCachedQueue(model.EndpointName)
+
+	return runWithRetryVoid(
+		ctx,
+		w.retryOptions(),
+		"Deleting queue"+model.EndpointName,
 		func() error {
 			_, err := w.Client.DeleteQueue(
 				ctx,
@@ -58,8 +65,11 @@ func (w *AsbClientWrapper) DeleteAdditionalQueue(
 	ctx context.Context,
 	queueName string,
 ) error {
-	return runWithRetryIncrementalBackOffVoid(
+	defer w.invalidateCachedQueue(queueName)
+
+	return runWithRetryVoid(
 		ctx,
+		w.retryOptions(),
 		"Deleting queue"+queueName,
 		func() error {
 			_, err := w.Client.DeleteQueue(
@@ -77,35 +87,22 @@ func (w *AsbClientWrapper) GetEndpointQueue(
 	ctx context.Context,
 	model AsbEndpointModel,
 ) (*az.GetQueueResponse, error) {
-	return runWithRetryIncrementalBackOff(
-		ctx,
-		"Getting queue"+model.EndpointName,
-		func() (*az.GetQueueResponse, error) {
-			return w.Client.GetQueue(
-				ctx,
-				model.EndpointName,
-				nil,
-			)
-		},
-	)
+	queue, err := w.getCachedQueue(ctx, model.EndpointName)
+	if err != nil || queue == nil {
+		return nil, err
+	}
+
+	return &az.GetQueueResponse{
+		QueueName:       model.EndpointName,
+		QueueProperties: *queue,
+	}, nil
 }
 
 func (w *AsbClientWrapper) QueueExists(ctx context.Context, queueName string) (bool, error) {
-	return runWithRetryIncrementalBackOff(
-		ctx,
-		"Getting queue"+queueName,
-		func() (bool, error) {
-			queue, err := w.Client.GetQueue(ctx, queueName, nil)
+	queue, err := w.getCachedQueue(ctx, queueName)
+	if err != nil {
+		return false, err
+	}
 
-			if err != nil {
-				return false, err
-			}
-
-			if queue == nil {
-				return false, nil
-			}
-
-			return true, nil
-		},
-	)
+	return queue != nil, nil
 }
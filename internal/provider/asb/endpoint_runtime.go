@@ -0,0 +1,44 @@
+package asb
+
+import (
+	"context"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+func (w *AsbClientWrapper) GetEndpointQueueRuntimeProperties(
+	ctx context.Context,
+	model AsbEndpointModel,
+) (*az.GetQueueRuntimePropertiesResponse, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Getting queue runtime properties "+model.EndpointName,
+		func() (*az.GetQueueRuntimePropertiesResponse, error) {
+			return w.Client.GetQueueRuntimeProperties(
+				ctx,
+				model.EndpointName,
+				nil,
+			)
+		},
+	)
+}
+
+func (w *AsbClientWrapper) GetEndpointSubscriptionRuntimeProperties(
+	ctx context.Context,
+	model AsbEndpointModel,
+) (*az.GetSubscriptionRuntimePropertiesResponse, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Getting subscription runtime properties "+model.EndpointName,
+		func() (*az.GetSubscriptionRuntimePropertiesResponse, error) {
+			return w.Client.GetSubscriptionRuntimeProperties(
+				ctx,
+				model.TopicName,
+				model.EndpointName,
+				nil,
+			)
+		},
+	)
+}
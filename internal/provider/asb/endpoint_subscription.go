@@ -18,6 +18,8 @@ type AsbSubscriptionRule struct {
 	Name       string // The name of the rule
 	Filter     string // The filter of the rule. When sql this is the complete Filter expression, when correlation this is the value application property "Dg.CorrelationFilterType"
 	FilterType string // The type of the filter. Can be "sql" or "correlation"
+
+	properties *az.RuleProperties // The rule as read from Service Bus, which a revert restores exactly
 }
 
 const MAX_RULE_NAME_LENGTH = 50
@@ -30,31 +32,24 @@ func (w *AsbClientWrapper) GetAsbSubscriptionsRules(
 	ctx context.Context,
 	model AsbEndpointModel,
 ) ([]AsbSubscriptionRule, error) {
+	rules, err := w.GetSubscriptionRules(ctx, model.TopicName, model.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+
 	subscriptions := []AsbSubscriptionRule{}
-	pager := w.Client.NewListRulesPager(
-		model.TopicName,
-		model.EndpointName,
-		nil,
-	)
+	for _, rule := range rules {
+		if rule.Name == "$Default" {
+			continue
+		}
 
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
+		subscription, err := convertToAsbSubscriptionRule(rule)
 		if err != nil {
-			return nil, err
-		}
-		for _, rule := range page.Rules {
-			if rule.Name == "$Default" {
-				continue
-			}
-
-			subscription, err := convertToAsbSubscriptionRule(rule)
-			if err != nil {
-				tflog.Error(ctx, "Error converting subscription rule: "+err.Error())
-				continue
-			}
-
-			subscriptions = append(subscriptions, *subscription)
+			tflog.Warn(ctx, "Skipping subscription rule "+rule.Name+", which could not be converted: "+err.Error())
+			continue
 		}
+
+		subscriptions = append(subscriptions, *subscription)
 	}
 
 	return subscriptions, nil
@@ -71,6 +66,7 @@ func convertToAsbSubscriptionRule(rule az.RuleProperties) (*AsbSubscriptionRule,
 			Name:       rule.Name,
 			Filter:     ruleFilterValue,
 			FilterType: "correlation",
+			properties: &rule,
 		}, nil
 	}
 
@@ -82,6 +78,7 @@ func convertToAsbSubscriptionRule(rule az.RuleProperties) (*AsbSubscriptionRule,
 			Name:       rule.Name,
 			Filter:     ruleFilter.Expression,
 			FilterType: "sql",
+			properties: &rule,
 		}, nil
 	}
 
@@ -93,11 +90,13 @@ func (w *AsbClientWrapper) CreateAsbSubscriptionRule(
 	model AsbEndpointModel,
 	subscription AsbSubscriptionModel,
 ) error {
+	defer w.invalidateCachedSubscriptionRules(model.TopicName, model.EndpointName)
 
-	// Retry 3 times as the create rule operation can fail with a 400 error,
-	// which is a transient error, if another operation is in progress
-	return runWithRetryIncrementalBackOffVoid(
+	// The create rule operation can fail with a 400 error, which is retried as a transient error,
+	// if another operation is in progress
+	return runWithRetryVoid(
 		ctx,
+		w.retryOptions(),
 		"Creating subscription rule "+subscription.Filter+" with filter type "+subscription.FilterType,
 		func() error {
 			_, err := w.Client.CreateRule(
@@ -132,12 +131,19 @@ func (w *AsbClientWrapper) GetAsbSubscriptionRule(
 	subscriptionRuleValue string,
 ) (*AsbSubscriptionRule, error) {
 	tflog.Info(ctx, "Get the subscription rule "+subscriptionRuleValue)
-	rule, err := w.Client.GetRule(
+	rule, err := runWithRetry(
 		ctx,
-		model.TopicName,
-		model.EndpointName,
-		w.encodeAsbSubscriptionRuleNameFromFitlerValue(subscriptionRuleValue),
-		nil,
+		w.retryOptions(),
+		"Getting subscription rule "+subscriptionRuleValue,
+		func() (*az.GetRuleResponse, error) {
+			return w.Client.GetRule(
+				ctx,
+				model.TopicName,
+				model.EndpointName,
+				w.encodeAsbSubscriptionRuleNameFromFitlerValue(subscriptionRuleValue),
+				nil,
+			)
+		},
 	)
 
 	if err != nil {
@@ -156,14 +162,22 @@ func (w *AsbClientWrapper) DeleteAsbSubscriptionRule(
 	model AsbEndpointModel,
 	subscription AsbSubscriptionModel,
 ) error {
-	ruleName := w.encodeAsbSubscriptionRuleNameFromFitlerValue(subscription.Filter)
+	return w.deleteAsbSubscriptionRuleByName(ctx, model, w.encodeAsbSubscriptionRuleNameFromFitlerValue(subscription.Filter))
+}
 
+func (w *AsbClientWrapper) deleteAsbSubscriptionRuleByName(
+	ctx context.Context,
+	model AsbEndpointModel,
+	ruleName string,
+) error {
 	tflog.Info(ctx, "Deleting subscription rule "+ruleName)
+	defer w.invalidateCachedSubscriptionRules(model.TopicName, model.EndpointName)
 
-	// Retry 3 times as the delete rule operation can fail with a 409 conflict error
+	// The delete rule operation can fail with a 409 conflict error, which is retried as a transient error,
 	// if another operation is in progress
-	return runWithRetryIncrementalBackOffVoid(
+	return runWithRetryVoid(
 		ctx,
+		w.retryOptions(),
 		"Deleting subscription rule "+ruleName,
 		func() error {
 			_, err := w.Client.DeleteRule(
@@ -184,11 +198,13 @@ func (w *AsbClientWrapper) UpdateAsbSubscriptionRule(
 	subscriptionModel AsbSubscriptionModel,
 ) error {
 	tflog.Info(ctx, "Updating subscription rule value "+subscriptionModel.Filter+" and filter type "+subscriptionModel.FilterType)
+	defer w.invalidateCachedSubscriptionRules(model.TopicName, model.EndpointName)
 
-	// Retry 3 times as the create rule operation can fail with a 400 error,
-	// which is a transient error, if another operation is in progress
-	return runWithRetryIncrementalBackOffVoid(
+	// The update rule operation can fail with a 400 error, which is retried as a transient error,
+	// if another operation is in progress
+	return runWithRetryVoid(
 		ctx,
+		w.retryOptions(),
 		"Updating subscription rule "+subscriptionModel.Filter+" with filter type"+subscriptionModel.FilterType,
 		func() error {
 			_, err := w.Client.UpdateRule(
@@ -283,3 +299,14 @@ func makeSubscriptionCorrelationRuleFilter(subscriptionFilterValue string) *az.C
 		},
 	}
 }
+
+// GetSubscriptionSqlFilterExpression returns the expression of the sql filter this provider creates for the filter value.
+func GetSubscriptionSqlFilterExpression(subscriptionFilterValue string) string {
+	return makeSubscriptionSqlRuleFilter(subscriptionFilterValue).Expression
+}
+
+// GetSubscriptionCorrelationFilterProperties returns the application properties of the correlation filter
+// this provider creates for the filter value.
+func GetSubscriptionCorrelationFilterProperties(subscriptionFilterValue string) map[string]string {
+	return convertRuleValuesToStrings(makeSubscriptionCorrelationRuleFilter(subscriptionFilterValue).ApplicationProperties)
+}
@@ -0,0 +1,92 @@
+package asb
+
+import (
+	"context"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+// Documented quotas, see https://learn.microsoft.com/en-us/azure/service-bus-messaging/service-bus-quotas
+const MAX_ENTITIES_PER_NAMESPACE = 10000
+const MAX_ENTITIES_PER_MESSAGING_UNIT = 1000
+const MAX_SUBSCRIPTIONS_PER_TOPIC = 2000
+
+const SKU_PREMIUM = "Premium"
+
+// The endpoint suffix of the public cloud, only used when the hostname of the client is unknown
+const DEFAULT_ENDPOINT_SUFFIX = "servicebus.windows.net"
+
+type AsbNamespaceEntityCounts struct {
+	QueueCount               int64
+	TopicCount               int64
+	SubscriptionCount        int64
+	MaxSubscriptionsPerTopic int64
+}
+
+type AsbNamespaceQuotas struct {
+	EntityLimit                int64 // The limit of queues and topics combined
+	SubscriptionsPerTopicLimit int64
+}
+
+func (w *AsbClientWrapper) GetNamespaceProperties(ctx context.Context) (az.NamespaceProperties, error) {
+	return w.getCachedNamespaceProperties(ctx)
+}
+
+func (w *AsbClientWrapper) GetNamespaceEntityCounts(ctx context.Context) (AsbNamespaceEntityCounts, error) {
+	counts := AsbNamespaceEntityCounts{}
+
+	queues, err := w.GetQueues(ctx)
+	if err != nil {
+		return counts, err
+	}
+	counts.QueueCount = int64(len(queues))
+
+	topics, err := w.GetTopics(ctx)
+	if err != nil {
+		return counts, err
+	}
+
+	for _, topic := range topics {
+		counts.TopicCount++
+
+		subscriptions, err := w.GetTopicSubscriptions(ctx, topic.TopicName)
+		if err != nil {
+			return counts, err
+		}
+
+		subscriptionCount := int64(len(subscriptions))
+		counts.SubscriptionCount += subscriptionCount
+		if subscriptionCount > counts.MaxSubscriptionsPerTopic {
+			counts.MaxSubscriptionsPerTopic = subscriptionCount
+		}
+	}
+
+	return counts, nil
+}
+
+func GetNamespaceQuotas(namespace az.NamespaceProperties) AsbNamespaceQuotas {
+	quotas := AsbNamespaceQuotas{
+		EntityLimit:                MAX_ENTITIES_PER_NAMESPACE,
+		SubscriptionsPerTopicLimit: MAX_SUBSCRIPTIONS_PER_TOPIC,
+	}
+
+	if namespace.SKU == SKU_PREMIUM && namespace.MessagingUnits != nil {
+		quotas.EntityLimit = MAX_ENTITIES_PER_MESSAGING_UNIT * *namespace.MessagingUnits
+	}
+
+	return quotas
+}
+
+// GetFullyQualifiedNamespace returns the base address of the namespace, for example sb://my-namespace.servicebus.windows.net/
+func (w *AsbClientWrapper) GetFullyQualifiedNamespace(ctx context.Context) (string, error) {
+	if w.Hostname != "" {
+		return "sb://" + w.Hostname + "/", nil
+	}
+
+	namespace, err := w.GetNamespaceProperties(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "sb://" + namespace.Name + "." + DEFAULT_ENDPOINT_SUFFIX + "/", nil
+}
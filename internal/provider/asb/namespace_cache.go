@@ -0,0 +1,296 @@
+package asb
+
+import (
+	"context"
+	"sync"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NamespaceCache keeps the entities read from the namespace for the lifetime of one provider process,
+// such that a plan over many endpoints does not read every queue, subscription and rule separately.
+// Queues are prefetched for the whole namespace, subscriptions for a whole topic. Rules are read per subscription,
+// as listing the rules of every subscription of a shared topic would cost a call per subscription.
+// Every mutation done through the AsbClientWrapper invalidates the entries it affects.
+type NamespaceCache struct {
+	mutex          sync.Mutex
+	namespace      cacheEntry[az.NamespaceProperties]
+	queuesPrefetch cacheEntry[bool] // Whether the queues were listed successfully
+	queues         entityCache[az.QueueProperties]
+	topics         map[string]*topicCache
+}
+
+type topicCache struct {
+	prefetch      cacheEntry[bool] // Whether the subscriptions were listed successfully
+	subscriptions entityCache[az.SubscriptionProperties]
+	rules         entityCache[[]az.RuleProperties] // Keyed by subscription name, read when first needed
+}
+
+func NewNamespaceCache() *NamespaceCache {
+	return &NamespaceCache{
+		topics: map[string]*topicCache{},
+	}
+}
+
+func (c *NamespaceCache) topic(topicName string) *topicCache {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	topic, ok := c.topics[topicName]
+	if !ok {
+		topic = &topicCache{}
+		c.topics[topicName] = topic
+	}
+
+	return topic
+}
+
+// cacheEntry loads its value once. Errors are not cached.
+type cacheEntry[T any] struct {
+	mutex  sync.Mutex
+	loaded bool
+	value  T
+}
+
+func (e *cacheEntry[T]) get(load func() (T, error)) (T, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.loaded {
+		return e.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	e.value = value
+	e.loaded = true
+	return value, nil
+}
+
+// entityCache holds entities by name, a nil entity is known not to exist.
+type entityCache[T any] struct {
+	mutex    sync.Mutex
+	entities map[string]*cacheEntry[*T]
+}
+
+// fill adds listed entities. Entities already in the cache are kept, as they may have been
+// invalidated after the listing was read.
+func (c *entityCache[T]) fill(entities map[string]T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entities == nil {
+		c.entities = map[string]*cacheEntry[*T]{}
+	}
+
+	for name := range entities {
+		if _, ok := c.entities[name]; ok {
+			continue
+		}
+
+		entity := entities[name]
+		c.entities[name] = &cacheEntry[*T]{loaded: true, value: &entity}
+	}
+}
+
+// get loads entities, which are not in the cache. When complete is true, the cache was filled with
+// a complete listing, so entities not in the cache do not exist.
+func (c *entityCache[T]) get(name string, complete bool, load func() (*T, error)) (*T, error) {
+	c.mutex.Lock()
+	if c.entities == nil {
+		c.entities = map[string]*cacheEntry[*T]{}
+	}
+
+	entry, ok := c.entities[name]
+	if !ok {
+		entry = &cacheEntry[*T]{loaded: complete}
+		c.entities[name] = entry
+	}
+	c.mutex.Unlock()
+
+	return entry.get(load)
+}
+
+func (c *entityCache[T]) invalidate(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entities == nil {
+		c.entities = map[string]*cacheEntry[*T]{}
+	}
+
+	c.entities[name] = &cacheEntry[*T]{}
+}
+
+func (w *AsbClientWrapper) getCachedNamespaceProperties(ctx context.Context) (az.NamespaceProperties, error) {
+	load := func() (az.NamespaceProperties, error) {
+		return runWithRetry(
+			ctx,
+			w.retryOptions(),
+			"Getting namespace properties",
+			func() (az.NamespaceProperties, error) {
+				response, err := w.Client.GetNamespaceProperties(ctx, nil)
+				return response.NamespaceProperties, err
+			},
+		)
+	}
+
+	if w.Cache == nil {
+		return load()
+	}
+
+	return w.Cache.namespace.get(load)
+}
+
+// getCachedQueue returns nil, when the queue does not exist.
+func (w *AsbClientWrapper) getCachedQueue(ctx context.Context, queueName string) (*az.QueueProperties, error) {
+	load := func() (*az.QueueProperties, error) {
+		return runWithRetry(
+			ctx,
+			w.retryOptions(),
+			"Getting queue "+queueName,
+			func() (*az.QueueProperties, error) {
+				queue, err := w.Client.GetQueue(ctx, queueName, nil)
+				if err != nil || queue == nil {
+					return nil, err
+				}
+				return &queue.QueueProperties, nil
+			},
+		)
+	}
+
+	if w.Cache == nil {
+		return load()
+	}
+
+	complete, _ := w.Cache.queuesPrefetch.get(func() (bool, error) {
+		tflog.Info(ctx, "Prefetching queues of the namespace")
+		queues, err := w.GetQueues(ctx)
+		if err != nil {
+			// Not retried for every queue, they are read separately instead
+			tflog.Warn(ctx, "Prefetching queues failed, they are read separately: "+err.Error())
+			return false, nil
+		}
+
+		entities := make(map[string]az.QueueProperties, len(queues))
+		for _, queue := range queues {
+			entities[queue.QueueName] = queue.QueueProperties
+		}
+		w.Cache.queues.fill(entities)
+		return true, nil
+	})
+
+	return w.Cache.queues.get(queueName, complete, load)
+}
+
+// getCachedSubscription returns nil, when the subscription does not exist.
+func (w *AsbClientWrapper) getCachedSubscription(
+	ctx context.Context,
+	topicName string,
+	subscriptionName string,
+) (*az.SubscriptionProperties, error) {
+	load := func() (*az.SubscriptionProperties, error) {
+		return runWithRetry(
+			ctx,
+			w.retryOptions(),
+			"Getting subscription "+subscriptionName,
+			func() (*az.SubscriptionProperties, error) {
+				subscription, err := w.Client.GetSubscription(ctx, topicName, subscriptionName, nil)
+				if err != nil || subscription == nil {
+					return nil, err
+				}
+				return &subscription.SubscriptionProperties, nil
+			},
+		)
+	}
+
+	if w.Cache == nil {
+		return load()
+	}
+
+	topic := w.Cache.topic(topicName)
+	complete := w.prefetchTopic(ctx, topicName, topic)
+
+	return topic.subscriptions.get(subscriptionName, complete, load)
+}
+
+func (w *AsbClientWrapper) getCachedSubscriptionRules(
+	ctx context.Context,
+	topicName string,
+	subscriptionName string,
+) ([]az.RuleProperties, error) {
+	load := func() (*[]az.RuleProperties, error) {
+		rules, err := w.listSubscriptionRules(ctx, topicName, subscriptionName)
+		if err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}
+
+	var rules *[]az.RuleProperties
+	var err error
+	if w.Cache == nil {
+		rules, err = load()
+	} else {
+		rules, err = w.Cache.topic(topicName).rules.get(subscriptionName, false, load)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return *rules, nil
+}
+
+// prefetchTopic lists the subscriptions of the topic once, and returns whether they could be listed.
+func (w *AsbClientWrapper) prefetchTopic(ctx context.Context, topicName string, topic *topicCache) bool {
+	complete, _ := topic.prefetch.get(func() (bool, error) {
+		tflog.Info(ctx, "Prefetching subscriptions of topic "+topicName)
+		subscriptions, err := w.GetTopicSubscriptions(ctx, topicName)
+		if err != nil {
+			// Not retried for every subscription, they are read separately instead
+			tflog.Warn(ctx, "Prefetching topic "+topicName+" failed, its subscriptions are read separately: "+err.Error())
+			return false, nil
+		}
+
+		subscriptionEntities := make(map[string]az.SubscriptionProperties, len(subscriptions))
+		for _, subscription := range subscriptions {
+			subscriptionEntities[subscription.SubscriptionName] = subscription.SubscriptionProperties
+		}
+
+		topic.subscriptions.fill(subscriptionEntities)
+		return true, nil
+	})
+
+	return complete
+}
+
+func (w *AsbClientWrapper) invalidateCachedQueue(queueName string) {
+	if w.Cache == nil {
+		return
+	}
+
+	w.Cache.queues.invalidate(queueName)
+}
+
+func (w *AsbClientWrapper) invalidateCachedSubscription(topicName string, subscriptionName string) {
+	if w.Cache == nil {
+		return
+	}
+
+	topic := w.Cache.topic(topicName)
+	topic.subscriptions.invalidate(subscriptionName)
+	topic.rules.invalidate(subscriptionName)
+}
+
+func (w *AsbClientWrapper) invalidateCachedSubscriptionRules(topicName string, subscriptionName string) {
+	if w.Cache == nil {
+		return
+	}
+
+	w.Cache.topic(topicName).rules.invalidate(subscriptionName)
+}
@@ -0,0 +1,138 @@
+package asb
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheEntry_LoadsOnce(t *testing.T) {
+	entry := cacheEntry[string]{}
+	loads := 0
+	load := func() (string, error) {
+		loads++
+		return "value", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := entry.get(load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	}
+	assert.Equal(t, 1, loads)
+}
+
+func TestCacheEntry_ErrorsAreNotCached(t *testing.T) {
+	entry := cacheEntry[string]{}
+
+	_, err := entry.get(func() (string, error) { return "", errors.New("throttled") })
+	assert.Error(t, err)
+
+	value, err := entry.get(func() (string, error) { return "value", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestCacheEntry_ConcurrentLoadsOnce(t *testing.T) {
+	entry := cacheEntry[int]{}
+	loads := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = entry.get(func() (int, error) {
+				loads++
+				return 1, nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, loads)
+}
+
+func unexpectedLoad(t *testing.T) func() (*string, error) {
+	return func() (*string, error) {
+		t.Error("entity should not be loaded separately")
+		return nil, nil
+	}
+}
+
+func loadValue(value string, loads *int) func() (*string, error) {
+	return func() (*string, error) {
+		*loads++
+		return &value, nil
+	}
+}
+
+func TestEntityCache_FilledEntitiesAreNotLoaded(t *testing.T) {
+	cache := entityCache[string]{}
+	cache.fill(map[string]string{"queue-1": "listed"})
+
+	entity, err := cache.get("queue-1", true, unexpectedLoad(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "listed", *entity)
+}
+
+func TestEntityCache_MissingEntitiesOfCompleteListingDoNotExist(t *testing.T) {
+	cache := entityCache[string]{}
+	cache.fill(map[string]string{"queue-1": "listed"})
+
+	entity, err := cache.get("queue-2", true, unexpectedLoad(t))
+	assert.NoError(t, err)
+	assert.Nil(t, entity)
+}
+
+func TestEntityCache_MissingEntitiesOfIncompleteListingAreLoaded(t *testing.T) {
+	cache := entityCache[string]{}
+	loads := 0
+
+	for i := 0; i < 2; i++ {
+		entity, err := cache.get("queue-1", false, loadValue("loaded", &loads))
+		assert.NoError(t, err)
+		assert.Equal(t, "loaded", *entity)
+	}
+	assert.Equal(t, 1, loads)
+}
+
+func TestEntityCache_InvalidatedEntitiesAreLoaded(t *testing.T) {
+	cache := entityCache[string]{}
+	cache.fill(map[string]string{"queue-1": "listed"})
+	loads := 0
+
+	cache.invalidate("queue-1")
+	entity, err := cache.get("queue-1", true, loadValue("loaded", &loads))
+	assert.NoError(t, err)
+	assert.Equal(t, "loaded", *entity)
+
+	// A queue created after the listing
+	cache.invalidate("queue-2")
+	entity, err = cache.get("queue-2", true, loadValue("created", &loads))
+	assert.NoError(t, err)
+	assert.Equal(t, "created", *entity)
+
+	assert.Equal(t, 2, loads)
+}
+
+func TestEntityCache_FillKeepsEntitiesInvalidatedBeforeIt(t *testing.T) {
+	cache := entityCache[string]{}
+	loads := 0
+
+	cache.invalidate("queue-1")
+	cache.fill(map[string]string{"queue-1": "outdated"})
+
+	entity, err := cache.get("queue-1", true, loadValue("loaded", &loads))
+	assert.NoError(t, err)
+	assert.Equal(t, "loaded", *entity)
+	assert.Equal(t, 1, loads)
+}
+
+func TestNamespaceCache_TopicsAreShared(t *testing.T) {
+	cache := NewNamespaceCache()
+
+	assert.Same(t, cache.topic("bundle-1"), cache.topic("bundle-1"))
+	assert.NotSame(t, cache.topic("bundle-1"), cache.topic("bundle-2"))
+}
@@ -0,0 +1,70 @@
+package asb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// NamespaceClients creates the client wrappers for namespaces other than the one of the provider, once per hostname.
+// The mutex only guards the map, such that creating a client, which may run a preflight, does not block other namespaces.
+type NamespaceClients struct {
+	mutex             sync.Mutex
+	clients           map[string]*cacheEntry[*AsbClientWrapper] // By lower case hostname, as hostnames are case-insensitive
+	normalizeHostname func(namespace string) (string, error)
+	newClient         func(hostname string) (*AsbClientWrapper, error)
+}
+
+// NewNamespaceClients creates the clients for the namespace overrides. The default client is returned for its own hostname,
+// such that its cache is shared.
+func NewNamespaceClients(
+	defaultClient *AsbClientWrapper,
+	normalizeHostname func(namespace string) (string, error),
+	newClient func(hostname string) (*AsbClientWrapper, error),
+) *NamespaceClients {
+	return &NamespaceClients{
+		clients:           map[string]*cacheEntry[*AsbClientWrapper]{strings.ToLower(defaultClient.Hostname): {loaded: true, value: defaultClient}},
+		normalizeHostname: normalizeHostname,
+		newClient:         newClient,
+	}
+}
+
+// ForNamespace returns the client for the namespace, which can be a namespace name, a hostname or an url.
+// The client itself is returned, when namespace is empty.
+func (w *AsbClientWrapper) ForNamespace(namespace string) (*AsbClientWrapper, error) {
+	if namespace == "" {
+		return w, nil
+	}
+
+	if w.Namespaces == nil {
+		return nil, fmt.Errorf("the provider does not support overriding the namespace")
+	}
+
+	return w.Namespaces.get(namespace)
+}
+
+func (n *NamespaceClients) get(namespace string) (*AsbClientWrapper, error) {
+	hostname, err := n.normalizeHostname(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(hostname)
+	n.mutex.Lock()
+	entry, ok := n.clients[key]
+	if !ok {
+		entry = &cacheEntry[*AsbClientWrapper]{}
+		n.clients[key] = entry
+	}
+	n.mutex.Unlock()
+
+	return entry.get(func() (*AsbClientWrapper, error) {
+		client, err := n.newClient(hostname)
+		if err != nil {
+			return nil, fmt.Errorf("could not create the client for namespace %s: %w", hostname, err)
+		}
+		client.Namespaces = n
+
+		return client, nil
+	})
+}
@@ -0,0 +1,135 @@
+package asb
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNamespaceClients(created *int) *AsbClientWrapper {
+	defaultClient := &AsbClientWrapper{Hostname: "default.servicebus.windows.net"}
+	defaultClient.Namespaces = NewNamespaceClients(
+		defaultClient,
+		func(namespace string) (string, error) {
+			if strings.Contains(namespace, " ") {
+				return "", fmt.Errorf("invalid namespace")
+			}
+			if !strings.Contains(namespace, ".") {
+				namespace += ".servicebus.windows.net"
+			}
+			return namespace, nil
+		},
+		func(hostname string) (*AsbClientWrapper, error) {
+			*created++
+			return &AsbClientWrapper{Hostname: hostname}, nil
+		},
+	)
+
+	return defaultClient
+}
+
+func TestForNamespace_EmptyReturnsDefault(t *testing.T) {
+	created := 0
+	defaultClient := newTestNamespaceClients(&created)
+
+	client, err := defaultClient.ForNamespace("")
+
+	require.NoError(t, err)
+	assert.Same(t, defaultClient, client)
+	assert.Equal(t, 0, created)
+}
+
+func TestForNamespace_OwnHostnameReturnsDefault(t *testing.T) {
+	created := 0
+	defaultClient := newTestNamespaceClients(&created)
+
+	client, err := defaultClient.ForNamespace("default")
+
+	require.NoError(t, err)
+	assert.Same(t, defaultClient, client)
+	assert.Equal(t, 0, created)
+}
+
+func TestForNamespace_CreatesClientOncePerHostname(t *testing.T) {
+	created := 0
+	defaultClient := newTestNamespaceClients(&created)
+
+	var wg sync.WaitGroup
+	clients := make([]*AsbClientWrapper, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			namespace := "Other"
+			if i%2 == 0 {
+				namespace = "other.servicebus.windows.net"
+			}
+			client, err := defaultClient.ForNamespace(namespace)
+			assert.NoError(t, err)
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	for _, client := range clients {
+		assert.Same(t, clients[0], client)
+	}
+	assert.Equal(t, "other.servicebus.windows.net", strings.ToLower(clients[0].Hostname))
+
+	// Clients of overridden namespaces resolve further overrides the same way
+	client, err := clients[0].ForNamespace("Default")
+	require.NoError(t, err)
+	assert.Same(t, defaultClient, client)
+}
+
+func TestForNamespace_SlowClientDoesNotBlockOtherNamespaces(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defaultClient := &AsbClientWrapper{Hostname: "default.servicebus.windows.net"}
+	defaultClient.Namespaces = NewNamespaceClients(
+		defaultClient,
+		func(namespace string) (string, error) { return namespace, nil },
+		func(hostname string) (*AsbClientWrapper, error) {
+			if hostname == "slow.servicebus.windows.net" {
+				close(started)
+				<-release
+			}
+			return &AsbClientWrapper{Hostname: hostname}, nil
+		},
+	)
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		_, err := defaultClient.ForNamespace("slow.servicebus.windows.net")
+		assert.NoError(t, err)
+	}()
+	<-started
+
+	client, err := defaultClient.ForNamespace("other.servicebus.windows.net")
+	require.NoError(t, err)
+	assert.Equal(t, "other.servicebus.windows.net", client.Hostname)
+
+	client, err = defaultClient.ForNamespace("default.servicebus.windows.net")
+	require.NoError(t, err)
+	assert.Same(t, defaultClient, client)
+
+	close(release)
+	<-slowDone
+}
+
+func TestForNamespace_Errors(t *testing.T) {
+	created := 0
+	defaultClient := newTestNamespaceClients(&created)
+
+	_, err := defaultClient.ForNamespace("my namespace")
+	assert.ErrorContains(t, err, "invalid namespace")
+
+	_, err = (&AsbClientWrapper{}).ForNamespace("other")
+	assert.ErrorContains(t, err, "does not support overriding")
+}
@@ -0,0 +1,18 @@
+package asb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFullyQualifiedName_UsesHostname(t *testing.T) {
+	w := &AsbClientWrapper{Hostname: "localhost:5300"}
+
+	name, err := w.GetFullyQualifiedName(context.Background(), "my-endpoint")
+
+	require.NoError(t, err)
+	assert.Equal(t, "sb://localhost:5300/my-endpoint", name)
+}
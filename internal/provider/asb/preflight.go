@@ -0,0 +1,30 @@
+package asb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+const DEFAULT_PREFLIGHT_TIMEOUT = 30 * time.Second
+
+// Preflight checks, that the namespace can be reached and managed with the credentials of the client.
+// Every call is attempted once, such that a misconfiguration is reported at once instead of after all retries.
+func (w *AsbClientWrapper) Preflight(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DEFAULT_PREFLIGHT_TIMEOUT)
+	defer cancel()
+
+	if _, err := w.Client.GetNamespaceProperties(ctx, nil); err != nil {
+		return fmt.Errorf("fetching the namespace properties failed: %w", err)
+	}
+
+	// Listing entities requires the Manage claim, which reading the namespace properties does not
+	pager := w.Client.NewListQueuesPager(&az.ListQueuesOptions{MaxPageSize: 1})
+	if _, err := pager.NextPage(ctx); err != nil {
+		return fmt.Errorf("listing the queues failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package asb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight_IsNotRetried(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	err := client.Preflight(context.Background())
+
+	var respError *azcore.ResponseError
+	require.True(t, errors.As(err, &respError))
+	assert.Equal(t, http.StatusUnauthorized, respError.StatusCode)
+	assert.ErrorContains(t, err, "fetching the namespace properties failed")
+	assert.Equal(t, 1, requests)
+}
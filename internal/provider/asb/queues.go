@@ -0,0 +1,55 @@
+package asb
+
+import (
+	"context"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+// The listings are retried as a whole, as a pager can not be continued after a failed page.
+
+func (w *AsbClientWrapper) GetQueues(ctx context.Context) ([]az.QueueItem, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Listing queues",
+		func() ([]az.QueueItem, error) {
+			queues := []az.QueueItem{}
+			pager := w.Client.NewListQueuesPager(nil)
+
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				queues = append(queues, page.Queues...)
+			}
+
+			return queues, nil
+		},
+	)
+}
+
+func (w *AsbClientWrapper) GetQueuesRuntimeProperties(ctx context.Context) ([]az.QueueRuntimePropertiesItem, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Listing queue runtime properties",
+		func() ([]az.QueueRuntimePropertiesItem, error) {
+			queues := []az.QueueRuntimePropertiesItem{}
+			pager := w.Client.NewListQueuesRuntimePropertiesPager(nil)
+
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				queues = append(queues, page.QueueRuntimeProperties...)
+			}
+
+			return queues, nil
+		},
+	)
+}
@@ -0,0 +1,126 @@
+package asb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+const DEFAULT_RATE_LIMIT_REQUESTS_PER_SECOND = 20
+const DEFAULT_RATE_LIMIT_BURST = 40
+
+// The time the rate needs to recover from being halved by a throttled request
+const RATE_LIMIT_RECOVERY_TIME = 30 * time.Second
+
+// RateLimiter is a token bucket shared by all calls of a provider instance. When Service Bus throttles,
+// all calls are paused for the requested time and the rate is halved, after which it slowly recovers.
+// A nil RateLimiter does not limit.
+type RateLimiter struct {
+	mutex       sync.Mutex
+	maxRate     float64 // Requests per second as configured
+	rate        float64 // Requests per second currently allowed
+	burst       float64
+	tokens      float64
+	updatedAt   time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = DEFAULT_RATE_LIMIT_REQUESTS_PER_SECOND
+	}
+	if burst < 1 {
+		burst = DEFAULT_RATE_LIMIT_BURST
+	}
+
+	return &RateLimiter{
+		maxRate:   requestsPerSecond,
+		rate:      requestsPerSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		updatedAt: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent, or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns the time to wait until a token may be available.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.updatedAt).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	l.updatedAt = now
+
+	l.rate = min(l.maxRate, l.rate+l.maxRate/2*elapsed/RATE_LIMIT_RECOVERY_TIME.Seconds())
+	l.tokens = min(l.burst, l.tokens+l.rate*elapsed)
+}
+
+// Throttled feeds a throttled request back to the limiter.
+func (l *RateLimiter) Throttled(retryAfter time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	l.rate = max(l.rate/2, l.maxRate/16)
+	l.tokens = 0
+	if pausedUntil := now.Add(retryAfter); pausedUntil.After(l.pausedUntil) {
+		l.pausedUntil = pausedUntil
+	}
+}
+
+func isThrottledError(err error) bool {
+	var respError *azcore.ResponseError
+	return errors.As(err, &respError) && respError.StatusCode == http.StatusTooManyRequests
+}
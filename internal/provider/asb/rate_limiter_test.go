@@ -0,0 +1,120 @@
+package asb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+func newTestRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := NewRateLimiter(requestsPerSecond, burst)
+	limiter.now = func() time.Time { return clock.now }
+	limiter.updatedAt = clock.now
+	return limiter, clock
+}
+
+func TestRateLimiter_AllowsBurst(t *testing.T) {
+	limiter, _ := newTestRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, limiter.reserve())
+	}
+	assert.Equal(t, time.Second, limiter.reserve())
+}
+
+func TestRateLimiter_RefillsAtRate(t *testing.T) {
+	limiter, clock := newTestRateLimiter(2, 1)
+
+	assert.Zero(t, limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+
+	clock.advance(500 * time.Millisecond)
+	assert.Zero(t, limiter.reserve())
+
+	// Tokens do not exceed the burst
+	clock.advance(time.Hour)
+	assert.Zero(t, limiter.reserve())
+	assert.NotZero(t, limiter.reserve())
+}
+
+func TestRateLimiter_ThrottledPausesAndHalvesRate(t *testing.T) {
+	limiter, clock := newTestRateLimiter(4, 4)
+
+	limiter.Throttled(3 * time.Second)
+	assert.Equal(t, 3*time.Second, limiter.reserve())
+	assert.Equal(t, 2.0, limiter.rate)
+
+	clock.advance(3 * time.Second)
+	assert.Zero(t, limiter.reserve())
+
+	// Recovers to the configured rate
+	clock.advance(RATE_LIMIT_RECOVERY_TIME)
+	limiter.reserve()
+	assert.Equal(t, 4.0, limiter.rate)
+}
+
+func TestRateLimiter_ThrottledKeepsLongerPause(t *testing.T) {
+	limiter, _ := newTestRateLimiter(4, 4)
+
+	limiter.Throttled(10 * time.Second)
+	limiter.Throttled(time.Second)
+
+	assert.Equal(t, 10*time.Second, limiter.reserve())
+}
+
+func TestRateLimiter_RateHasLowerBound(t *testing.T) {
+	limiter, _ := newTestRateLimiter(16, 1)
+
+	for i := 0; i < 10; i++ {
+		limiter.Throttled(0)
+	}
+
+	assert.Equal(t, 1.0, limiter.rate)
+}
+
+func TestRateLimiter_WaitStopsWhenContextIsCancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	limiter.Throttled(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
+
+func TestRateLimiter_NilDoesNotLimit(t *testing.T) {
+	var limiter *RateLimiter
+
+	limiter.Throttled(time.Hour)
+	assert.NoError(t, limiter.Wait(context.Background()))
+}
+
+func TestRunWithRetry_FeedsThrottlingToRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100, 1)
+	options := testRetryOptions
+	options.limiter = limiter
+
+	attempts := 0
+	err := runWithRetryVoid(context.Background(), options, "Testing", func() error {
+		attempts++
+		if attempts == 1 {
+			return responseError(http.StatusTooManyRequests, "", http.Header{"Retry-After-Ms": []string{"0"}})
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 50.0, limiter.rate, 5.0)
+}
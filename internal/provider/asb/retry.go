@@ -2,41 +2,115 @@ package asb
 
 import (
 	"context"
-	"math"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-func runWithRetryIncrementalBackOff[TResult any](
+const DEFAULT_RETRY_MAX_ATTEMPTS = 5
+const DEFAULT_RETRY_INITIAL_BACKOFF = 2 * time.Second
+const DEFAULT_RETRY_MAX_BACKOFF = 60 * time.Second
+
+type RetryOptions struct {
+	MaxAttempts    int           // The number of attempts including the first one, DEFAULT_RETRY_MAX_ATTEMPTS when not set
+	InitialBackoff time.Duration // The backoff before the first retry, doubled for every further retry, DEFAULT_RETRY_INITIAL_BACKOFF when not set
+	MaxBackoff     time.Duration // The upper bound of the backoff, DEFAULT_RETRY_MAX_BACKOFF when not set
+
+	limiter *RateLimiter // Every attempt waits for the limiter, which is informed about throttled attempts
+}
+
+func (w *AsbClientWrapper) retryOptions() RetryOptions {
+	options := w.Retry
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = DEFAULT_RETRY_MAX_ATTEMPTS
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DEFAULT_RETRY_INITIAL_BACKOFF
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = DEFAULT_RETRY_MAX_BACKOFF
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = options.InitialBackoff
+	}
+	options.limiter = w.RateLimiter
+
+	return options
+}
+
+// runWithRetry retries transient errors with an exponential backoff and jitter. When Service Bus
+// throttles, the Retry-After header is honoured instead. It stops as soon as the context is done.
+func runWithRetry[TResult any](
 	ctx context.Context,
+	options RetryOptions,
 	actionMessage string,
 	fun func() (TResult, error),
 ) (TResult, error) {
 	var err error
 	var res TResult
-	for i := 1; i < 6; i++ {
+	for attempt := 1; ; attempt++ {
+		if err := options.limiter.Wait(ctx); err != nil {
+			return res, getContextDoneError(ctx, actionMessage, nil)
+		}
+
 		res, err = fun()
 		if err == nil {
 			return res, nil
 		}
 
-		tflog.Info(ctx, actionMessage+" failed with error "+err.Error()+", retrying")
+		if ctx.Err() != nil {
+			return res, getContextDoneError(ctx, actionMessage, err)
+		}
 
-		backOff := time.Second * time.Duration(math.Pow(2, float64(i)))
-		time.Sleep(backOff)
-	}
+		if !isRetriableError(err) {
+			return res, err
+		}
 
-	return res, err
+		delay, ok := getRetryAfter(err)
+		if !ok {
+			delay = getBackoffWithJitter(options, attempt)
+		}
+
+		if isThrottledError(err) {
+			options.limiter.Throttled(delay)
+		}
+
+		if attempt >= options.MaxAttempts {
+			return res, fmt.Errorf("%s failed after %d attempts: %w", actionMessage, attempt, err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("%s failed with error %s, retrying in %s", actionMessage, err.Error(), delay))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, getContextDoneError(ctx, actionMessage, err)
+		case <-timer.C:
+		}
+	}
 }
 
-func runWithRetryIncrementalBackOffVoid(
+func runWithRetryVoid(
 	ctx context.Context,
+	options RetryOptions,
 	actionMessage string,
 	fun func() error,
 ) error {
-	_, err := runWithRetryIncrementalBackOff(
+	_, err := runWithRetry(
 		ctx,
+		options,
 		actionMessage,
 		func() (interface{}, error) {
 			return nil, fun()
@@ -45,3 +119,111 @@ func runWithRetryIncrementalBackOffVoid(
 
 	return err
 }
+
+// getContextDoneError names the action, which timed out or was cancelled, as the errors of the
+// Azure SDK only contain the request URL.
+func getContextDoneError(ctx context.Context, actionMessage string, lastErr error) error {
+	reason := "was cancelled"
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = "timed out"
+	}
+
+	if lastErr == nil || errors.Is(lastErr, ctx.Err()) {
+		return fmt.Errorf("%s %s: %w", actionMessage, reason, ctx.Err())
+	}
+
+	return fmt.Errorf("%s %s: %w, the last attempt failed with: %w", actionMessage, reason, ctx.Err(), lastErr)
+}
+
+// isRetriableError classifies the errors returned by Service Bus. Errors without a response are only retried,
+// when they are known to be transient, like timeouts and connection resets. Authentication, DNS, certificate
+// and configuration errors fail at once, as retrying them only delays the error.
+func isRetriableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var respError *azcore.ResponseError
+	if !errors.As(err, &respError) {
+		return isTransientTransportError(err)
+	}
+
+	message := strings.ToLower(respError.Error())
+	switch respError.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		// Retrying a create, which already succeeded, would never succeed
+		return !strings.Contains(message, "already exist") && !strings.Contains(message, "alreadyexist")
+	case http.StatusBadRequest:
+		// Service Bus answers with a 400 while another operation on the same entity is in progress,
+		// all other 400s are validation errors
+		return strings.Contains(message, "conflicting operation") || strings.Contains(message, "in progress")
+	default:
+		return false
+	}
+}
+
+func isTransientTransportError(err error) bool {
+	var authenticationError *azidentity.AuthenticationFailedError
+	if errors.As(err, &authenticationError) {
+		return false
+	}
+
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return dnsError.IsTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError) && netError.Timeout()
+}
+
+// getRetryAfter returns the delay requested by Service Bus when it throttles requests.
+func getRetryAfter(err error) (time.Duration, bool) {
+	var respError *azcore.ResponseError
+	if !errors.As(err, &respError) || respError.RawResponse == nil {
+		return 0, false
+	}
+
+	header := respError.RawResponse.Header
+	for _, name := range []string{"retry-after-ms", "x-ms-retry-after-ms"} {
+		if milliseconds, err := strconv.Atoi(header.Get(name)); err == nil && milliseconds >= 0 {
+			return time.Duration(milliseconds) * time.Millisecond, true
+		}
+	}
+
+	retryAfter := header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// getBackoffWithJitter returns a random delay between half and the full exponential backoff of the attempt.
+func getBackoffWithJitter(options RetryOptions, attempt int) time.Duration {
+	backoff := options.InitialBackoff
+	for i := 1; i < attempt && backoff < options.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, options.MaxBackoff)
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,228 @@
+package asb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryOptions = RetryOptions{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+func responseError(statusCode int, message string, header http.Header) error {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &azcore.ResponseError{
+		ErrorCode:   message,
+		StatusCode:  statusCode,
+		RawResponse: &http.Response{StatusCode: statusCode, Header: header},
+	}
+}
+
+func TestIsRetriableError(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		err       error
+		retriable bool
+	}{
+		{"throttled", responseError(http.StatusTooManyRequests, "", nil), true},
+		{"timeout", responseError(http.StatusRequestTimeout, "", nil), true},
+		{"server error", responseError(http.StatusInternalServerError, "", nil), true},
+		{"unavailable", responseError(http.StatusServiceUnavailable, "", nil), true},
+		{"conflicting operation", responseError(http.StatusConflict, "Another conflicting operation is in progress", nil), true},
+		{"already exists", responseError(http.StatusConflict, "MessagingEntityAlreadyExists", nil), false},
+		{"bad request in progress", responseError(http.StatusBadRequest, "An operation is in progress on the entity", nil), true},
+		{"validation", responseError(http.StatusBadRequest, "The filter is invalid", nil), false},
+		{"unauthorized", responseError(http.StatusUnauthorized, "", nil), false},
+		{"forbidden", responseError(http.StatusForbidden, "", nil), false},
+		{"not found", responseError(http.StatusNotFound, "", nil), false},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true},
+		{"unexpected eof", fmt.Errorf("reading the response: %w", io.ErrUnexpectedEOF), true},
+		{"network timeout", &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}, true},
+		{"dns timeout", &net.DNSError{Err: "i/o timeout", Name: "test.servicebus.windows.net", IsTimeout: true}, true},
+		{"dns not found", &net.DNSError{Err: "no such host", Name: "test.servicebus.windows.net", IsNotFound: true}, false},
+		{"authentication", &azidentity.AuthenticationFailedError{}, false},
+		{"certificate", &tls.CertificateVerificationError{Err: errors.New("x509: certificate signed by unknown authority")}, false},
+		{"configuration", errors.New("unknown authentication method"), false},
+		{"cancelled", context.Canceled, false},
+		{"deadline", context.DeadlineExceeded, false},
+	} {
+		assert.Equal(t, test.retriable, isRetriableError(test.err), test.name)
+	}
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	delay, ok := getRetryAfter(responseError(http.StatusTooManyRequests, "", http.Header{"Retry-After": []string{"7"}}))
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, delay)
+
+	delay, ok = getRetryAfter(responseError(http.StatusTooManyRequests, "", http.Header{"Retry-After-Ms": []string{"250"}}))
+	assert.True(t, ok)
+	assert.Equal(t, 250*time.Millisecond, delay)
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	delay, ok = getRetryAfter(responseError(http.StatusTooManyRequests, "", http.Header{"Retry-After": []string{date}}))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Minute, delay, float64(2*time.Second))
+
+	_, ok = getRetryAfter(responseError(http.StatusTooManyRequests, "", nil))
+	assert.False(t, ok)
+
+	_, ok = getRetryAfter(errors.New("connection reset by peer"))
+	assert.False(t, ok)
+}
+
+func TestGetBackoffWithJitter(t *testing.T) {
+	options := RetryOptions{MaxAttempts: 10, InitialBackoff: 2 * time.Second, MaxBackoff: 10 * time.Second}
+
+	for _, test := range []struct {
+		attempt int
+		backoff time.Duration
+	}{
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{9, 10 * time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			delay := getBackoffWithJitter(options, test.attempt)
+			assert.GreaterOrEqual(t, delay, test.backoff/2, test.attempt)
+			assert.LessOrEqual(t, delay, test.backoff, test.attempt)
+		}
+	}
+}
+
+func TestRunWithRetry_RetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	result, err := runWithRetry(context.Background(), testRetryOptions, "Testing", func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", responseError(http.StatusServiceUnavailable, "", nil)
+		}
+		return "done", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "done", result)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRunWithRetry_StopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	err := runWithRetryVoid(context.Background(), testRetryOptions, "Testing", func() error {
+		attempts++
+		return responseError(http.StatusTooManyRequests, "", nil)
+	})
+
+	assert.ErrorContains(t, err, "Testing failed after 3 attempts")
+	assert.Equal(t, 3, attempts)
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRunWithRetry_DoesNotRetryAuthenticationAndDnsErrors(t *testing.T) {
+	for _, expected := range []error{
+		&azidentity.AuthenticationFailedError{},
+		&net.DNSError{Err: "no such host", Name: "test.servicebus.windows.net", IsNotFound: true},
+	} {
+		attempts := 0
+		err := runWithRetryVoid(context.Background(), testRetryOptions, "Testing", func() error {
+			attempts++
+			return expected
+		})
+
+		assert.Same(t, expected, err)
+		assert.Equal(t, 1, attempts)
+	}
+}
+
+func TestRunWithRetry_DoesNotRetryPermanentErrors(t *testing.T) {
+	attempts := 0
+	expected := responseError(http.StatusForbidden, "", nil)
+	err := runWithRetryVoid(context.Background(), testRetryOptions, "Testing", func() error {
+		attempts++
+		return expected
+	})
+
+	assert.Same(t, expected, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRunWithRetry_StopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	options := RetryOptions{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	attempts := 0
+	start := time.Now()
+	err := runWithRetryVoid(ctx, options, "Testing", func() error {
+		attempts++
+		cancel()
+		return responseError(http.StatusServiceUnavailable, "", nil)
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestRunWithRetry_HonoursRetryAfter(t *testing.T) {
+	options := RetryOptions{MaxAttempts: 2, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	attempts := 0
+	start := time.Now()
+	err := runWithRetryVoid(context.Background(), options, "Testing", func() error {
+		attempts++
+		if attempts == 1 {
+			return responseError(http.StatusTooManyRequests, "", http.Header{"Retry-After-Ms": []string{"10"}})
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestRetryOptions_Defaults(t *testing.T) {
+	w := &AsbClientWrapper{}
+
+	assert.Equal(t, RetryOptions{
+		MaxAttempts:    DEFAULT_RETRY_MAX_ATTEMPTS,
+		InitialBackoff: DEFAULT_RETRY_INITIAL_BACKOFF,
+		MaxBackoff:     DEFAULT_RETRY_MAX_BACKOFF,
+	}, w.retryOptions())
+}
+
+func TestRunWithRetry_NamesActionOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	options := RetryOptions{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	err := runWithRetryVoid(ctx, options, "Creating queue my-endpoint", func() error {
+		return responseError(http.StatusServiceUnavailable, "", nil)
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "Creating queue my-endpoint timed out")
+}
@@ -0,0 +1,162 @@
+package asb
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+// The expression of the sql filter of the rules created by this provider. The first group is the message type.
+var managedSqlFilterExpressionRegex = regexp.MustCompile(`^\[NServiceBus\.EnclosedMessageTypes\] LIKE '%([a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*)%'$`)
+
+// AsbSubscriptionRuleDetails contains the raw properties of any rule, also the ones not created by this provider.
+type AsbSubscriptionRuleDetails struct {
+	Name              string
+	FilterType        string // Can be "sql", "correlation", "true", "false" or "unknown"
+	SqlExpression     *string
+	SqlParameters     map[string]string
+	CorrelationFilter *AsbCorrelationFilterDetails
+	Action            *string // The expression of the sql action, if any
+	ManagedFormat     bool    // Whether the rule has the format of the rules created by this provider
+	ManagedFilter     *string // The filter value as used in the endpoint resource, only set when ManagedFormat is true
+}
+
+type AsbCorrelationFilterDetails struct {
+	AsbMessageSystemProperties
+	ApplicationProperties map[string]string
+}
+
+// GetSubscriptionRuleName returns the name of the rule this provider creates for the filter value.
+// Long filter values are cropped and suffixed with a hash, see getRuleNameWithUniqueIdentifier.
+func GetSubscriptionRuleName(subscriptionFilterValue string) string {
+	return getRuleNameWithUniqueIdentifier(subscriptionFilterValue)
+}
+
+// GetSubscriptionRuleDetails returns nil, when the rule does not exist.
+func (w *AsbClientWrapper) GetSubscriptionRuleDetails(
+	ctx context.Context,
+	topicName string,
+	subscriptionName string,
+	ruleName string,
+) (*AsbSubscriptionRuleDetails, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Getting subscription rule "+ruleName,
+		func() (*AsbSubscriptionRuleDetails, error) {
+			rule, err := w.Client.GetRule(ctx, topicName, subscriptionName, ruleName, nil)
+			if err != nil || rule == nil {
+				return nil, err
+			}
+
+			details := GetAsbSubscriptionRuleDetails(rule.RuleProperties)
+			return &details, nil
+		},
+	)
+}
+
+func GetAsbSubscriptionRuleDetails(rule az.RuleProperties) AsbSubscriptionRuleDetails {
+	details := AsbSubscriptionRuleDetails{
+		Name:       rule.Name,
+		FilterType: GetRuleFilterType(rule.Filter),
+	}
+
+	switch ruleFilter := rule.Filter.(type) {
+	case *az.SQLFilter:
+		details.SqlExpression = &ruleFilter.Expression
+		details.SqlParameters = convertRuleValuesToStrings(ruleFilter.Parameters)
+	case *az.CorrelationFilter:
+		details.CorrelationFilter = &AsbCorrelationFilterDetails{
+			AsbMessageSystemProperties: AsbMessageSystemProperties{
+				MessageID:        ruleFilter.MessageID,
+				CorrelationID:    ruleFilter.CorrelationID,
+				To:               ruleFilter.To,
+				ReplyTo:          ruleFilter.ReplyTo,
+				Subject:          ruleFilter.Subject,
+				SessionID:        ruleFilter.SessionID,
+				ReplyToSessionID: ruleFilter.ReplyToSessionID,
+				ContentType:      ruleFilter.ContentType,
+			},
+			ApplicationProperties: convertRuleValuesToStrings(ruleFilter.ApplicationProperties),
+		}
+	}
+
+	if ruleAction, ok := rule.Action.(*az.SQLAction); ok {
+		details.Action = &ruleAction.Expression
+	}
+
+	if managedFilter, ok := GetManagedSubscriptionFilterValue(rule); ok {
+		details.ManagedFormat = true
+		details.ManagedFilter = &managedFilter
+	}
+
+	return details
+}
+
+// GetManagedSubscriptionFilterValue returns the filter value of the endpoint resource, if the rule has
+// the exact format of the rules created by this provider.
+func GetManagedSubscriptionFilterValue(rule az.RuleProperties) (string, bool) {
+	if rule.Action != nil {
+		return "", false
+	}
+
+	var filterValue string
+	switch ruleFilter := rule.Filter.(type) {
+	case *az.SQLFilter:
+		value, ok := DecodeManagedSqlFilterExpression(ruleFilter.Expression)
+		if !ok || len(ruleFilter.Parameters) > 0 {
+			return "", false
+		}
+		filterValue = value
+	case *az.CorrelationFilter:
+		value, ok := ruleFilter.ApplicationProperties[CORRELATIONFILTER_HEADER].(string)
+		if !ok || len(ruleFilter.ApplicationProperties) != 1 || hasCorrelationSystemProperties(ruleFilter) {
+			return "", false
+		}
+		filterValue = value
+	default:
+		return "", false
+	}
+
+	if rule.Name != getRuleNameWithUniqueIdentifier(filterValue) {
+		return "", false
+	}
+
+	return filterValue, true
+}
+
+// DecodeManagedSqlFilterExpression returns the message type of a sql filter expression created by this provider.
+func DecodeManagedSqlFilterExpression(expression string) (string, bool) {
+	foundFilter := managedSqlFilterExpressionRegex.FindStringSubmatch(expression)
+	if foundFilter == nil {
+		return "", false
+	}
+
+	return foundFilter[1], true
+}
+
+func hasCorrelationSystemProperties(filter *az.CorrelationFilter) bool {
+	return filter.MessageID != nil ||
+		filter.CorrelationID != nil ||
+		filter.To != nil ||
+		filter.ReplyTo != nil ||
+		filter.Subject != nil ||
+		filter.SessionID != nil ||
+		filter.ReplyToSessionID != nil ||
+		filter.ContentType != nil
+}
+
+func convertRuleValuesToStrings(values map[string]any) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	converted := make(map[string]string, len(values))
+	for key, value := range values {
+		converted[key] = fmt.Sprint(value)
+	}
+
+	return converted
+}
@@ -0,0 +1,83 @@
+package asb
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAsbSubscriptionRuleDetails_ManagedRules(t *testing.T) {
+	messageType := "Dg.SalesOrder.V1.SalesOrderCreated"
+
+	for _, filter := range []az.RuleFilter{
+		makeSubscriptionSqlRuleFilter(messageType),
+		makeSubscriptionCorrelationRuleFilter(messageType),
+	} {
+		details := GetAsbSubscriptionRuleDetails(az.RuleProperties{
+			Name:   getRuleNameWithUniqueIdentifier(messageType),
+			Filter: filter,
+		})
+
+		assert.True(t, details.ManagedFormat, details.FilterType)
+		assert.Equal(t, messageType, *details.ManagedFilter, details.FilterType)
+	}
+}
+
+func TestGetAsbSubscriptionRuleDetails_ForeignSqlRule(t *testing.T) {
+	details := GetAsbSubscriptionRuleDetails(az.RuleProperties{
+		Name: "Dg.SalesOrder.V1.SalesOrderCreated",
+		Filter: &az.SQLFilter{
+			Expression: "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.SalesOrder.V1.SalesOrderCreated%' AND Priority > @priority",
+			Parameters: map[string]any{"@priority": int64(3)},
+		},
+		Action: &az.SQLAction{Expression: "SET Routed = TRUE"},
+	})
+
+	assert.Equal(t, "sql", details.FilterType)
+	assert.False(t, details.ManagedFormat)
+	assert.Nil(t, details.ManagedFilter)
+	assert.Equal(t, "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.SalesOrder.V1.SalesOrderCreated%' AND Priority > @priority", *details.SqlExpression)
+	assert.Equal(t, map[string]string{"@priority": "3"}, details.SqlParameters)
+	assert.Equal(t, "SET Routed = TRUE", *details.Action)
+	assert.Nil(t, details.CorrelationFilter)
+}
+
+func TestGetAsbSubscriptionRuleDetails_ForeignCorrelationRule(t *testing.T) {
+	details := GetAsbSubscriptionRuleDetails(az.RuleProperties{
+		Name: "Dg.SalesOrder.V1.SalesOrderCreated",
+		Filter: &az.CorrelationFilter{
+			Subject: to.Ptr("order"),
+			ApplicationProperties: map[string]any{
+				CORRELATIONFILTER_HEADER: "Dg.SalesOrder.V1.SalesOrderCreated",
+			},
+		},
+	})
+
+	assert.Equal(t, "correlation", details.FilterType)
+	assert.False(t, details.ManagedFormat)
+	assert.Equal(t, "order", *details.CorrelationFilter.Subject)
+	assert.Equal(t, map[string]string{CORRELATIONFILTER_HEADER: "Dg.SalesOrder.V1.SalesOrderCreated"}, details.CorrelationFilter.ApplicationProperties)
+}
+
+func TestGetAsbSubscriptionRuleDetails_RuleNameMismatch(t *testing.T) {
+	details := GetAsbSubscriptionRuleDetails(az.RuleProperties{
+		Name:   "custom-name",
+		Filter: makeSubscriptionSqlRuleFilter("Dg.SalesOrder.V1.SalesOrderCreated"),
+	})
+
+	assert.False(t, details.ManagedFormat)
+}
+
+func TestGetAsbSubscriptionRuleDetails_TrueFilter(t *testing.T) {
+	details := GetAsbSubscriptionRuleDetails(az.RuleProperties{
+		Name:   "$Default",
+		Filter: &az.TrueFilter{},
+	})
+
+	assert.Equal(t, "true", details.FilterType)
+	assert.False(t, details.ManagedFormat)
+	assert.Nil(t, details.SqlExpression)
+	assert.Nil(t, details.CorrelationFilter)
+}
@@ -0,0 +1,148 @@
+package asb
+
+import (
+	"fmt"
+	"strings"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+// AsbMessage is a sample message, which is used to evaluate subscription rules locally.
+type AsbMessage struct {
+	ApplicationProperties map[string]string
+	SystemProperties      AsbMessageSystemProperties
+}
+
+type AsbMessageSystemProperties struct {
+	MessageID        *string
+	CorrelationID    *string
+	To               *string
+	ReplyTo          *string
+	Subject          *string
+	SessionID        *string
+	ReplyToSessionID *string
+	ContentType      *string
+}
+
+func GetRuleFilterType(filter az.RuleFilter) string {
+	switch filter.(type) {
+	case *az.SQLFilter:
+		return "sql"
+	case *az.CorrelationFilter:
+		return "correlation"
+	case *az.TrueFilter:
+		return "true"
+	case *az.FalseFilter:
+		return "false"
+	default:
+		return "unknown"
+	}
+}
+
+// EvaluateRuleFilter checks if the message would be routed by the given rule filter.
+// Rule actions are ignored, as they only modify the message after it has been routed.
+func EvaluateRuleFilter(filter az.RuleFilter, message AsbMessage) (bool, error) {
+	switch ruleFilter := filter.(type) {
+	case *az.TrueFilter:
+		return true, nil
+	case *az.FalseFilter:
+		return false, nil
+	case *az.CorrelationFilter:
+		return evaluateCorrelationFilter(ruleFilter, message), nil
+	case *az.SQLFilter:
+		return evaluateSqlFilter(ruleFilter.Expression, ruleFilter.Parameters, message.lookupProperty)
+	case *az.UnknownRuleFilter:
+		return false, fmt.Errorf("filter type %s is not supported", ruleFilter.Type)
+	default:
+		return false, fmt.Errorf("rule has no filter")
+	}
+}
+
+func evaluateCorrelationFilter(filter *az.CorrelationFilter, message AsbMessage) bool {
+	systemProperties := []struct {
+		expected *string
+		actual   *string
+	}{
+		{filter.MessageID, message.SystemProperties.MessageID},
+		{filter.CorrelationID, message.SystemProperties.CorrelationID},
+		{filter.To, message.SystemProperties.To},
+		{filter.ReplyTo, message.SystemProperties.ReplyTo},
+		{filter.Subject, message.SystemProperties.Subject},
+		{filter.SessionID, message.SystemProperties.SessionID},
+		{filter.ReplyToSessionID, message.SystemProperties.ReplyToSessionID},
+		{filter.ContentType, message.SystemProperties.ContentType},
+	}
+
+	for _, property := range systemProperties {
+		if property.expected == nil {
+			continue
+		}
+		if property.actual == nil || *property.actual != *property.expected {
+			return false
+		}
+	}
+
+	for name, expected := range filter.ApplicationProperties {
+		actual, ok := message.ApplicationProperties[name]
+		if !ok || actual != fmt.Sprint(expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupProperty resolves a property reference of a SQL filter. Unquoted references may
+// use the `sys.` and `user.` prefixes to select system or application properties.
+func (m AsbMessage) lookupProperty(name string, quoted bool) (interface{}, bool) {
+	if !quoted {
+		lowerName := strings.ToLower(name)
+		if strings.HasPrefix(lowerName, "sys.") {
+			return m.lookupSystemProperty(lowerName[len("sys."):])
+		}
+		if strings.HasPrefix(lowerName, "user.") {
+			name = name[len("user."):]
+		}
+	}
+
+	if value, ok := m.ApplicationProperties[name]; ok {
+		return value, true
+	}
+
+	for key, value := range m.ApplicationProperties {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m AsbMessage) lookupSystemProperty(name string) (interface{}, bool) {
+	var value *string
+
+	switch name {
+	case "messageid":
+		value = m.SystemProperties.MessageID
+	case "correlationid":
+		value = m.SystemProperties.CorrelationID
+	case "to":
+		value = m.SystemProperties.To
+	case "replyto":
+		value = m.SystemProperties.ReplyTo
+	case "label", "subject":
+		value = m.SystemProperties.Subject
+	case "sessionid":
+		value = m.SystemProperties.SessionID
+	case "replytosessionid":
+		value = m.SystemProperties.ReplyToSessionID
+	case "contenttype":
+		value = m.SystemProperties.ContentType
+	}
+
+	if value == nil {
+		return nil, false
+	}
+
+	return *value, true
+}
@@ -0,0 +1,109 @@
+package asb
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateRuleFilter_SqlFilter(t *testing.T) {
+	message := AsbMessage{
+		ApplicationProperties: map[string]string{
+			"NServiceBus.EnclosedMessageTypes": "Dg.SalesOrder.V1.SalesOrderCreated, Dg.SalesOrder, Version=1.0.0.0",
+			"Priority":                         "5",
+			"IsTest":                           "true",
+		},
+		SystemProperties: AsbMessageSystemProperties{
+			Subject: to.Ptr("order"),
+		},
+	}
+
+	cases := map[string]bool{
+		makeSubscriptionSqlRuleFilter("Dg.SalesOrder.V1.SalesOrderCreated").Expression: true,
+		makeSubscriptionSqlRuleFilter("Dg.SalesOrder.V1.SalesOrderDeleted").Expression: false,
+		"[NServiceBus.EnclosedMessageTypes] NOT LIKE '%Deleted%'":                      true,
+		"Priority > 3 AND Priority <= 5":                                               true,
+		"Priority + 1 = 6":                                                             true,
+		"Priority IN (1, 2, 3)":                                                        false,
+		"sys.Label = 'order'":                                                          true,
+		"sys.Label = 'invoice' OR IsTest = TRUE":                                       true,
+		"EXISTS(Priority) AND NOT EXISTS(Missing)":                                     true,
+		"Missing = 'x'":       false,
+		"NOT (Missing = 'x')": false,
+		"Missing IS NULL":     true,
+		"user.Priority = '5'": true,
+		"'it''s' = 'it''s'":   true,
+		"Priority LIKE '_'":   true,
+		"[NServiceBus.EnclosedMessageTypes] LIKE 'Dg!_%' ESCAPE '!'": false,
+		"1=1": true,
+	}
+
+	for expression, expected := range cases {
+		matches, err := EvaluateRuleFilter(&az.SQLFilter{Expression: expression}, message)
+		assert.Nil(t, err, expression)
+		assert.Equal(t, expected, matches, expression)
+	}
+}
+
+func TestEvaluateRuleFilter_SqlFilterParameters(t *testing.T) {
+	message := AsbMessage{ApplicationProperties: map[string]string{"Region": "CH"}}
+
+	matches, err := EvaluateRuleFilter(&az.SQLFilter{
+		Expression: "Region = @region",
+		Parameters: map[string]any{"@region": "CH"},
+	}, message)
+
+	assert.Nil(t, err)
+	assert.True(t, matches)
+}
+
+func TestEvaluateRuleFilter_SqlFilterErrors(t *testing.T) {
+	expressions := []string{
+		"newid() = 'x'",
+		"Priority = ",
+		"[Unterminated = 1",
+		"'unterminated = 1",
+		"Region = @undefined",
+		"Priority ! 1",
+	}
+
+	for _, expression := range expressions {
+		_, err := EvaluateRuleFilter(&az.SQLFilter{Expression: expression}, AsbMessage{})
+		assert.NotNil(t, err, expression)
+	}
+}
+
+func TestEvaluateRuleFilter_CorrelationFilter(t *testing.T) {
+	filter := makeSubscriptionCorrelationRuleFilter("Dg.SalesOrder.V1.SalesOrderCreated")
+
+	matches, err := EvaluateRuleFilter(filter, AsbMessage{
+		ApplicationProperties: map[string]string{CORRELATIONFILTER_HEADER: "Dg.SalesOrder.V1.SalesOrderCreated"},
+	})
+	assert.Nil(t, err)
+	assert.True(t, matches)
+
+	matches, err = EvaluateRuleFilter(filter, AsbMessage{
+		ApplicationProperties: map[string]string{CORRELATIONFILTER_HEADER: "Dg.SalesOrder.V1.SalesOrderDeleted"},
+	})
+	assert.Nil(t, err)
+	assert.False(t, matches)
+
+	matches, err = EvaluateRuleFilter(&az.CorrelationFilter{Subject: to.Ptr("order")}, AsbMessage{})
+	assert.Nil(t, err)
+	assert.False(t, matches)
+}
+
+func TestEvaluateRuleFilter_BooleanFilters(t *testing.T) {
+	matches, err := EvaluateRuleFilter(&az.TrueFilter{}, AsbMessage{})
+	assert.Nil(t, err)
+	assert.True(t, matches)
+
+	matches, err = EvaluateRuleFilter(&az.FalseFilter{}, AsbMessage{})
+	assert.Nil(t, err)
+	assert.False(t, matches)
+
+	_, err = EvaluateRuleFilter(&az.UnknownRuleFilter{Type: "Custom"}, AsbMessage{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,92 @@
+package asb
+
+import (
+	"fmt"
+)
+
+// AsbRulePlanDescription explains a rule plan to reviewers, with a line per operation and the routing impact.
+type AsbRulePlanDescription struct {
+	Operations []string
+	Impacts    []string
+}
+
+// GetExpectedAsbSubscriptionRule returns the rule this provider creates for the subscription, as GetAsbSubscriptionsRules
+// would return it. It allows to plan the rules of subscriptions, which are only known from the state.
+func GetExpectedAsbSubscriptionRule(subscription AsbSubscriptionModel) AsbSubscriptionRule {
+	rule := AsbSubscriptionRule{
+		Name:       getRuleNameWithUniqueIdentifier(subscription.Filter),
+		Filter:     subscription.Filter,
+		FilterType: subscription.FilterType,
+	}
+	if subscription.FilterType == "sql" {
+		rule.Filter = makeSubscriptionSqlRuleFilter(subscription.Filter).Expression
+	}
+
+	return rule
+}
+
+// DescribeAsbRulePlan describes the operations of the plan, which was planned against the existing rules.
+// As creates and updates are applied before deletes, replacing a filter never leaves a gap.
+func DescribeAsbRulePlan(plan AsbRulePlan, existing []AsbSubscriptionRule) AsbRulePlanDescription {
+	existingRules := map[string]AsbSubscriptionRule{}
+	for _, rule := range existing {
+		existingRules[rule.Name] = rule
+	}
+
+	description := AsbRulePlanDescription{
+		Operations: []string{},
+		Impacts:    []string{},
+	}
+
+	for _, operation := range plan.Creates {
+		description.Operations = append(description.Operations,
+			fmt.Sprintf("+ create rule %q (%s) for %s", operation.RuleName, operation.Subscription.FilterType, operation.Subscription.Filter))
+		description.Impacts = append(description.Impacts,
+			fmt.Sprintf("%s is received as soon as its rule is created.", operation.Subscription.Filter))
+	}
+
+	for _, operation := range plan.Updates {
+		existingRule := existingRules[operation.RuleName]
+		description.Operations = append(description.Operations,
+			fmt.Sprintf("~ update rule %q in place (%s -> %s) for %s", operation.RuleName, existingRule.FilterType, operation.Subscription.FilterType, operation.Subscription.Filter))
+		description.Impacts = append(description.Impacts, describeRuleUpdateImpact(existingRule, operation.Subscription))
+	}
+
+	for _, operation := range plan.Deletes {
+		messageType := getRuleMessageType(AsbSubscriptionRule{Name: operation.RuleName, Filter: operation.Subscription.Filter, FilterType: operation.Subscription.FilterType})
+		description.Operations = append(description.Operations,
+			fmt.Sprintf("- delete rule %q (%s) for %s", operation.RuleName, operation.Subscription.FilterType, messageType))
+		if len(plan.Creates) > 0 || len(plan.Updates) > 0 {
+			description.Impacts = append(description.Impacts,
+				fmt.Sprintf("%s is no longer received, once all creates and updates succeeded.", messageType))
+		} else {
+			description.Impacts = append(description.Impacts, fmt.Sprintf("%s is no longer received.", messageType))
+		}
+	}
+
+	return description
+}
+
+func describeRuleUpdateImpact(existingRule AsbSubscriptionRule, subscription AsbSubscriptionModel) string {
+	switch {
+	case existingRule.FilterType == "sql" && subscription.FilterType == "correlation":
+		return fmt.Sprintf("%s is matched by the application property %s instead of NServiceBus.EnclosedMessageTypes. "+
+			"Messages without this property are no longer received.", subscription.Filter, CORRELATIONFILTER_HEADER)
+	case existingRule.FilterType == "correlation" && subscription.FilterType == "sql":
+		return fmt.Sprintf("%s is matched by NServiceBus.EnclosedMessageTypes instead of the application property %s. "+
+			"Messages, which enclose the type or a type containing its name, are received as well.", subscription.Filter, CORRELATIONFILTER_HEADER)
+	default:
+		return fmt.Sprintf("The malformed rule of %s is corrected, the message type is received throughout.", subscription.Filter)
+	}
+}
+
+// getRuleMessageType returns the filter value of the rule, or its sql expression, when it was not created by this provider.
+func getRuleMessageType(rule AsbSubscriptionRule) string {
+	if rule.FilterType == "sql" {
+		if messageType, ok := DecodeManagedSqlFilterExpression(rule.Filter); ok {
+			return messageType
+		}
+	}
+
+	return rule.Filter
+}
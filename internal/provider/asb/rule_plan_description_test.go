@@ -0,0 +1,51 @@
+package asb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExpectedAsbSubscriptionRule_IsKeptByThePlan(t *testing.T) {
+	subscriptions := []AsbSubscriptionModel{sqlSubscription("Dg.Test.V1.Created"), correlationSubscription("Dg.Test.V1.Updated")}
+	existing := []AsbSubscriptionRule{}
+	for _, subscription := range subscriptions {
+		existing = append(existing, GetExpectedAsbSubscriptionRule(subscription))
+	}
+
+	assert.True(t, PlanAsbSubscriptionRules(subscriptions, existing).IsEmpty())
+}
+
+func TestDescribeAsbRulePlan(t *testing.T) {
+	existing := []AsbSubscriptionRule{
+		GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Switched")),
+		GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Removed")),
+	}
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{correlationSubscription("Dg.Test.V1.Switched"), sqlSubscription("Dg.Test.V1.Added")},
+		existing,
+	)
+
+	description := DescribeAsbRulePlan(plan, existing)
+
+	assert.Equal(t, []string{
+		`+ create rule "Dg.Test.V1.Added" (sql) for Dg.Test.V1.Added`,
+		`~ update rule "Dg.Test.V1.Switched" in place (sql -> correlation) for Dg.Test.V1.Switched`,
+		`- delete rule "Dg.Test.V1.Removed" (sql) for Dg.Test.V1.Removed`,
+	}, description.Operations)
+	assert.Equal(t, []string{
+		"Dg.Test.V1.Added is received as soon as its rule is created.",
+		"Dg.Test.V1.Switched is matched by the application property Dg.MessageTypeFullName instead of NServiceBus.EnclosedMessageTypes. " +
+			"Messages without this property are no longer received.",
+		"Dg.Test.V1.Removed is no longer received, once all creates and updates succeeded.",
+	}, description.Impacts)
+}
+
+func TestDescribeAsbRulePlan_ForeignRule(t *testing.T) {
+	existing := []AsbSubscriptionRule{{Name: "manual", Filter: "1=1", FilterType: "sql"}}
+
+	description := DescribeAsbRulePlan(PlanAsbSubscriptionRules([]AsbSubscriptionModel{}, existing), existing)
+
+	assert.Equal(t, []string{`- delete rule "manual" (sql) for 1=1`}, description.Operations)
+	assert.Equal(t, []string{"1=1 is no longer received."}, description.Impacts)
+}
@@ -0,0 +1,247 @@
+package asb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/exp/slices"
+)
+
+type AsbRuleOperationType string
+
+const (
+	RULE_OPERATION_CREATE AsbRuleOperationType = "create"
+	RULE_OPERATION_UPDATE AsbRuleOperationType = "update"
+	RULE_OPERATION_DELETE AsbRuleOperationType = "delete"
+)
+
+type AsbRuleOperation struct {
+	Type         AsbRuleOperationType
+	RuleName     string
+	Subscription AsbSubscriptionModel // The desired subscription, or for deletes the subscription as it exists in Azure
+}
+
+// AsbRulePlan is applied in order: Creates and Updates first, Deletes only when all of them succeeded.
+// Like this the endpoint never misses events while its subscriptions are replaced.
+type AsbRulePlan struct {
+	Creates []AsbRuleOperation
+	Updates []AsbRuleOperation
+	Deletes []AsbRuleOperation
+}
+
+func (p AsbRulePlan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+func (p AsbRulePlan) Operations() []AsbRuleOperation {
+	operations := []AsbRuleOperation{}
+	operations = append(operations, p.Creates...)
+	operations = append(operations, p.Updates...)
+	operations = append(operations, p.Deletes...)
+	return operations
+}
+
+// PlanAsbSubscriptionRules compares the desired subscriptions with a snapshot of the existing rules,
+// as returned by GetAsbSubscriptionsRules, and returns the operations needed to reach the desired state.
+// Rules are matched by name, so every existing rule, which is not desired, is deleted.
+func PlanAsbSubscriptionRules(desired []AsbSubscriptionModel, existing []AsbSubscriptionRule) AsbRulePlan {
+	plan := AsbRulePlan{
+		Creates: []AsbRuleOperation{},
+		Updates: []AsbRuleOperation{},
+		Deletes: []AsbRuleOperation{},
+	}
+
+	existingRules := map[string]AsbSubscriptionRule{}
+	for _, rule := range existing {
+		existingRules[rule.Name] = rule
+	}
+
+	desiredRuleNames := map[string]bool{}
+	for _, subscription := range desired {
+		ruleName := getRuleNameWithUniqueIdentifier(subscription.Filter)
+		if desiredRuleNames[ruleName] {
+			// The same filter twice results in the same rule
+			continue
+		}
+		desiredRuleNames[ruleName] = true
+
+		operation := AsbRuleOperation{
+			RuleName:     ruleName,
+			Subscription: subscription,
+		}
+
+		rule, exists := existingRules[ruleName]
+		switch {
+		case !exists:
+			operation.Type = RULE_OPERATION_CREATE
+			plan.Creates = append(plan.Creates, operation)
+		case rule.FilterType != subscription.FilterType || !IsAsbSubscriptionRuleCorrect(rule, subscription):
+			operation.Type = RULE_OPERATION_UPDATE
+			plan.Updates = append(plan.Updates, operation)
+		}
+	}
+
+	for _, rule := range existing {
+		if desiredRuleNames[rule.Name] {
+			continue
+		}
+
+		plan.Deletes = append(plan.Deletes, AsbRuleOperation{
+			Type:     RULE_OPERATION_DELETE,
+			RuleName: rule.Name,
+			Subscription: AsbSubscriptionModel{
+				Filter:     rule.Filter,
+				FilterType: rule.FilterType,
+			},
+		})
+	}
+
+	return plan
+}
+
+// ApplyAsbRulePlan runs the operations of the plan in parallel, bounded by MaxConcurrentRuleOperations.
+// The deletes are skipped, if any create or update failed. It returns the operations, which succeeded, in
+// the order of the plan, such that they can be recorded or reverted.
+func (w *AsbClientWrapper) ApplyAsbRulePlan(
+	ctx context.Context,
+	model AsbEndpointModel,
+	plan AsbRulePlan,
+) ([]AsbRuleOperation, []error) {
+	var mutex sync.Mutex
+	succeeded := map[AsbRuleOperationType]map[string]bool{}
+	apply := func(ctx context.Context, operation AsbRuleOperation) error {
+		if err := w.applyAsbRuleOperation(ctx, model, operation); err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		if succeeded[operation.Type] == nil {
+			succeeded[operation.Type] = map[string]bool{}
+		}
+		succeeded[operation.Type][operation.RuleName] = true
+		return nil
+	}
+
+	upserts := append(append([]AsbRuleOperation{}, plan.Creates...), plan.Updates...)
+	errs := runConcurrently(ctx, w.ruleOperationConcurrency(), upserts, apply)
+	if len(errs) == 0 {
+		errs = runConcurrently(ctx, w.ruleOperationConcurrency(), plan.Deletes, apply)
+	}
+
+	completed := []AsbRuleOperation{}
+	for _, operation := range plan.Operations() {
+		if succeeded[operation.Type][operation.RuleName] {
+			completed = append(completed, operation)
+		}
+	}
+
+	return completed, errs
+}
+
+// RevertAsbRuleOperation undoes an operation of ApplyAsbRulePlan. Updates and deletes restore the rule as it
+// existed before, which is one of the existing rules the plan was planned against. They must have been listed
+// by GetAsbSubscriptionsRules, as only the rules read from Service Bus can be restored exactly.
+func (w *AsbClientWrapper) RevertAsbRuleOperation(
+	ctx context.Context,
+	model AsbEndpointModel,
+	operation AsbRuleOperation,
+	existing []AsbSubscriptionRule,
+) error {
+	tflog.Info(ctx, fmt.Sprintf("Reverting %s of rule %s", operation.Type, operation.RuleName))
+
+	if operation.Type == RULE_OPERATION_CREATE {
+		err := w.deleteAsbSubscriptionRuleByName(ctx, model, operation.RuleName)
+		if err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("reverting the create of rule %s failed: %w", operation.RuleName, err)
+		}
+		return nil
+	}
+
+	index := slices.IndexFunc(existing, func(rule AsbSubscriptionRule) bool { return rule.Name == operation.RuleName })
+	if index < 0 {
+		return fmt.Errorf("reverting the %s of rule %s failed: the rule did not exist before", operation.Type, operation.RuleName)
+	}
+
+	if err := w.restoreAsbSubscriptionRule(ctx, model, existing[index], operation.Type == RULE_OPERATION_UPDATE); err != nil {
+		return fmt.Errorf("reverting the %s of rule %s failed: %w", operation.Type, operation.RuleName, err)
+	}
+
+	return nil
+}
+
+// restoreAsbSubscriptionRule creates or updates the rule with the exact filter and action, which were read from
+// Service Bus, as the rule might not have been created by this provider.
+func (w *AsbClientWrapper) restoreAsbSubscriptionRule(
+	ctx context.Context,
+	model AsbEndpointModel,
+	rule AsbSubscriptionRule,
+	update bool,
+) error {
+	if rule.properties == nil {
+		return fmt.Errorf("the rule was not read from Service Bus")
+	}
+	properties := *rule.properties
+
+	defer w.invalidateCachedSubscriptionRules(model.TopicName, model.EndpointName)
+
+	return runWithRetryVoid(
+		ctx,
+		w.retryOptions(),
+		"Restoring subscription rule "+rule.Name,
+		func() error {
+			var err error
+			if update {
+				_, err = w.Client.UpdateRule(ctx, model.TopicName, model.EndpointName, properties)
+			} else {
+				_, err = w.Client.CreateRule(ctx, model.TopicName, model.EndpointName, &az.CreateRuleOptions{
+					Name:   &properties.Name,
+					Filter: properties.Filter,
+					Action: properties.Action,
+				})
+			}
+
+			return err
+		})
+}
+
+func (w *AsbClientWrapper) applyAsbRuleOperation(
+	ctx context.Context,
+	model AsbEndpointModel,
+	operation AsbRuleOperation,
+) error {
+	tflog.Info(ctx, fmt.Sprintf("Applying %s of rule %s", operation.Type, operation.RuleName))
+
+	var err error
+	switch operation.Type {
+	case RULE_OPERATION_CREATE:
+		err = w.CreateAsbSubscriptionRule(ctx, model, operation.Subscription)
+	case RULE_OPERATION_UPDATE:
+		err = w.UpdateAsbSubscriptionRule(ctx, model, operation.Subscription)
+	case RULE_OPERATION_DELETE:
+		err = w.deleteAsbSubscriptionRuleByName(ctx, model, operation.RuleName)
+		if isNotFoundError(err) {
+			// Already deleted, which is the desired state
+			err = nil
+		}
+	default:
+		err = fmt.Errorf("invalid rule operation type %s", operation.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s of rule %s for %s failed: %w", operation.Type, operation.RuleName, operation.Subscription.Filter, err)
+	}
+
+	return nil
+}
+
+func isNotFoundError(err error) bool {
+	var respError *azcore.ResponseError
+	return errors.As(err, &respError) && respError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,354 @@
+package asb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sqlSubscription(filter string) AsbSubscriptionModel {
+	return AsbSubscriptionModel{Filter: filter, FilterType: "sql"}
+}
+
+func correlationSubscription(filter string) AsbSubscriptionModel {
+	return AsbSubscriptionModel{Filter: filter, FilterType: "correlation"}
+}
+
+func ruleNames(operations []AsbRuleOperation) []string {
+	names := []string{}
+	for _, operation := range operations {
+		names = append(names, operation.RuleName)
+	}
+	return names
+}
+
+func TestPlanAsbSubscriptionRules_NoRules(t *testing.T) {
+	plan := PlanAsbSubscriptionRules([]AsbSubscriptionModel{}, []AsbSubscriptionRule{})
+
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestPlanAsbSubscriptionRules_CreatesMissingRules(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			sqlSubscription("Dg.Test.V1.Created"),
+			correlationSubscription("Dg.Test.V1.Updated"),
+		},
+		[]AsbSubscriptionRule{},
+	)
+
+	assert.Equal(t, []AsbRuleOperation{
+		{Type: RULE_OPERATION_CREATE, RuleName: "Dg.Test.V1.Created", Subscription: sqlSubscription("Dg.Test.V1.Created")},
+		{Type: RULE_OPERATION_CREATE, RuleName: "Dg.Test.V1.Updated", Subscription: correlationSubscription("Dg.Test.V1.Updated")},
+	}, plan.Creates)
+	assert.Empty(t, plan.Updates)
+	assert.Empty(t, plan.Deletes)
+}
+
+func TestPlanAsbSubscriptionRules_CorrectRulesAreKept(t *testing.T) {
+	desired := []AsbSubscriptionModel{
+		sqlSubscription("Dg.Test.V1.Created"),
+		correlationSubscription("Dg.Test.V1.Updated"),
+	}
+
+	plan := PlanAsbSubscriptionRules(desired, []AsbSubscriptionRule{
+		GetExpectedAsbSubscriptionRule(desired[1]),
+		GetExpectedAsbSubscriptionRule(desired[0]),
+	})
+
+	assert.True(t, plan.IsEmpty())
+}
+
+func TestPlanAsbSubscriptionRules_UpdatesChangedFilterType(t *testing.T) {
+	for _, test := range []struct {
+		existing AsbSubscriptionModel
+		desired  AsbSubscriptionModel
+	}{
+		{sqlSubscription("Dg.Test.V1.Created"), correlationSubscription("Dg.Test.V1.Created")},
+		{correlationSubscription("Dg.Test.V1.Created"), sqlSubscription("Dg.Test.V1.Created")},
+	} {
+		plan := PlanAsbSubscriptionRules(
+			[]AsbSubscriptionModel{test.desired},
+			[]AsbSubscriptionRule{GetExpectedAsbSubscriptionRule(test.existing)},
+		)
+
+		assert.Empty(t, plan.Creates, test.desired.FilterType)
+		assert.Equal(t, []AsbRuleOperation{
+			{Type: RULE_OPERATION_UPDATE, RuleName: "Dg.Test.V1.Created", Subscription: test.desired},
+		}, plan.Updates, test.desired.FilterType)
+		assert.Empty(t, plan.Deletes, test.desired.FilterType)
+	}
+}
+
+func TestPlanAsbSubscriptionRules_UpdatesMalformedRules(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			sqlSubscription("Dg.Test.V1.Created"),
+			correlationSubscription("Dg.Test.V1.Updated"),
+		},
+		[]AsbSubscriptionRule{
+			{Name: "Dg.Test.V1.Created", Filter: "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.Test.V1.Other%'", FilterType: "sql"},
+			{Name: "Dg.Test.V1.Updated", Filter: "Dg.Test.V1.Other", FilterType: "correlation"},
+		},
+	)
+
+	assert.Empty(t, plan.Creates)
+	assert.Equal(t, []string{"Dg.Test.V1.Created", "Dg.Test.V1.Updated"}, ruleNames(plan.Updates))
+	assert.Empty(t, plan.Deletes)
+}
+
+func TestPlanAsbSubscriptionRules_DeletesUndesiredRules(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{sqlSubscription("Dg.Test.V1.Created")},
+		[]AsbSubscriptionRule{
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Created")),
+			GetExpectedAsbSubscriptionRule(correlationSubscription("Dg.Test.V1.Deleted")),
+		},
+	)
+
+	assert.Empty(t, plan.Creates)
+	assert.Empty(t, plan.Updates)
+	assert.Equal(t, []AsbRuleOperation{
+		{Type: RULE_OPERATION_DELETE, RuleName: "Dg.Test.V1.Deleted", Subscription: correlationSubscription("Dg.Test.V1.Deleted")},
+	}, plan.Deletes)
+}
+
+func TestPlanAsbSubscriptionRules_DeletesForeignRulesByTheirName(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{},
+		[]AsbSubscriptionRule{
+			{Name: "foreign-rule", Filter: "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.Test.V1.Created%'", FilterType: "sql"},
+		},
+	)
+
+	assert.Equal(t, []string{"foreign-rule"}, ruleNames(plan.Deletes))
+	assert.Equal(t, "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.Test.V1.Created%'", plan.Deletes[0].Subscription.Filter)
+}
+
+func TestPlanAsbSubscriptionRules_ReplacesAllRules(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			sqlSubscription("Dg.Test.V2.Created"),
+			sqlSubscription("Dg.Test.V2.Updated"),
+		},
+		[]AsbSubscriptionRule{
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Created")),
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Updated")),
+		},
+	)
+
+	assert.Equal(t, []string{"Dg.Test.V2.Created", "Dg.Test.V2.Updated"}, ruleNames(plan.Creates))
+	assert.Empty(t, plan.Updates)
+	assert.Equal(t, []string{"Dg.Test.V1.Created", "Dg.Test.V1.Updated"}, ruleNames(plan.Deletes))
+	assert.Equal(t,
+		[]string{"Dg.Test.V2.Created", "Dg.Test.V2.Updated", "Dg.Test.V1.Created", "Dg.Test.V1.Updated"},
+		ruleNames(plan.Operations()),
+	)
+}
+
+func TestPlanAsbSubscriptionRules_MixedChanges(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			sqlSubscription("Dg.Test.V1.Kept"),
+			correlationSubscription("Dg.Test.V1.Changed"),
+			sqlSubscription("Dg.Test.V1.Added"),
+		},
+		[]AsbSubscriptionRule{
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Removed")),
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Changed")),
+			GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Kept")),
+		},
+	)
+
+	assert.Equal(t, []string{"Dg.Test.V1.Added"}, ruleNames(plan.Creates))
+	assert.Equal(t, []string{"Dg.Test.V1.Changed"}, ruleNames(plan.Updates))
+	assert.Equal(t, []string{"Dg.Test.V1.Removed"}, ruleNames(plan.Deletes))
+}
+
+func TestPlanAsbSubscriptionRules_DuplicateDesiredSubscriptions(t *testing.T) {
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			sqlSubscription("Dg.Test.V1.Created"),
+			sqlSubscription("Dg.Test.V1.Created"),
+		},
+		[]AsbSubscriptionRule{},
+	)
+
+	assert.Equal(t, []string{"Dg.Test.V1.Created"}, ruleNames(plan.Creates))
+}
+
+func TestPlanAsbSubscriptionRules_LongFilterValues(t *testing.T) {
+	longFilter := "Dg.Test.V1." + strings.Repeat("VeryLongMessageTypeName", 5)
+	ruleName := getRuleNameWithUniqueIdentifier(longFilter)
+
+	plan := PlanAsbSubscriptionRules([]AsbSubscriptionModel{sqlSubscription(longFilter)}, []AsbSubscriptionRule{})
+	assert.Equal(t, []string{ruleName}, ruleNames(plan.Creates))
+	assert.LessOrEqual(t, len(ruleName), MAX_RULE_NAME_LENGTH)
+
+	plan = PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{sqlSubscription(longFilter)},
+		[]AsbSubscriptionRule{GetExpectedAsbSubscriptionRule(sqlSubscription(longFilter))},
+	)
+	assert.True(t, plan.IsEmpty())
+}
+
+// readRule returns the rule, as it is listed from Service Bus.
+func readRule(t *testing.T, properties az.RuleProperties) AsbSubscriptionRule {
+	rule, err := convertToAsbSubscriptionRule(properties)
+	require.NoError(t, err)
+	return *rule
+}
+
+// newRuleTestClient returns a client of a namespace, which rejects every rule containing Failing and accepts all others.
+func newRuleTestClient(t *testing.T) (*AsbClientWrapper, func() []string) {
+	var mutex sync.Mutex
+	requests := []string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mutex.Unlock()
+
+		if strings.Contains(r.URL.Path, "Failing") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `<entry xmlns="http://www.w3.org/2005/Atom"><content type="application/xml">`+
+			`<RuleDescription xmlns="http://schemas.microsoft.com/netservices/2010/10/servicebus/connect">`+
+			`<Filter xmlns:i="http://www.w3.org/2001/XMLSchema-instance" i:type="FalseFilter"><SqlExpression>1=0</SqlExpression></Filter>`+
+			`<Action xmlns:i="http://www.w3.org/2001/XMLSchema-instance" i:type="EmptyRuleAction"></Action>`+
+			`</RuleDescription></content></entry>`)
+	})
+	client.Retry = RetryOptions{MaxAttempts: 1}
+
+	return client, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, requests...)
+	}
+}
+
+func TestApplyAsbRulePlan_ReturnsTheCompletedOperations(t *testing.T) {
+	client, _ := newRuleTestClient(t)
+	model := AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+
+	plan := PlanAsbSubscriptionRules(
+		[]AsbSubscriptionModel{
+			correlationSubscription("Dg.Test.V1.Created"),
+			correlationSubscription("Dg.Test.V1.Failing"),
+		},
+		[]AsbSubscriptionRule{GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Deleted"))},
+	)
+
+	completed, errs := client.ApplyAsbRulePlan(context.Background(), model, plan)
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, []string{"Dg.Test.V1.Created"}, ruleNames(completed), "the delete is skipped after the failed create")
+}
+
+func TestRevertAsbRuleOperation(t *testing.T) {
+	model := AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+	existing := []AsbSubscriptionRule{readRule(t, az.RuleProperties{
+		Name:   "Dg.Test.V1.Updated",
+		Filter: makeSubscriptionSqlRuleFilter("Dg.Test.V1.Updated"),
+	})}
+
+	tests := []struct {
+		operation AsbRuleOperation
+		request   string
+	}{
+		{
+			operation: AsbRuleOperation{Type: RULE_OPERATION_CREATE, RuleName: "Dg.Test.V1.Created", Subscription: correlationSubscription("Dg.Test.V1.Created")},
+			request:   "DELETE /bundle-1/Subscriptions/endpoint/Rules/Dg.Test.V1.Created",
+		},
+		{
+			operation: AsbRuleOperation{Type: RULE_OPERATION_UPDATE, RuleName: "Dg.Test.V1.Updated", Subscription: correlationSubscription("Dg.Test.V1.Updated")},
+			request:   "PUT /bundle-1/Subscriptions/endpoint/Rules/Dg.Test.V1.Updated",
+		},
+	}
+
+	for _, test := range tests {
+		client, requests := newRuleTestClient(t)
+
+		err := client.RevertAsbRuleOperation(context.Background(), model, test.operation, existing)
+
+		assert.NoError(t, err, test.operation.Type)
+		assert.Equal(t, []string{test.request}, requests(), test.operation.Type)
+	}
+}
+
+func TestRevertAsbRuleOperation_FailsForUnknownRules(t *testing.T) {
+	client, requests := newRuleTestClient(t)
+	model := AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+
+	err := client.RevertAsbRuleOperation(
+		context.Background(),
+		model,
+		AsbRuleOperation{Type: RULE_OPERATION_DELETE, RuleName: "Dg.Test.V1.Deleted", Subscription: sqlSubscription("Dg.Test.V1.Deleted")},
+		[]AsbSubscriptionRule{},
+	)
+
+	assert.ErrorContains(t, err, "the rule did not exist before")
+	assert.Empty(t, requests())
+}
+
+func TestRevertAsbRuleOperation_RestoresTheRuleAsRead(t *testing.T) {
+	var body string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `<entry xmlns="http://www.w3.org/2005/Atom"><content type="application/xml">`+
+			`<RuleDescription xmlns="http://schemas.microsoft.com/netservices/2010/10/servicebus/connect">`+
+			`<Filter xmlns:i="http://www.w3.org/2001/XMLSchema-instance" i:type="FalseFilter"><SqlExpression>1=0</SqlExpression></Filter>`+
+			`<Action xmlns:i="http://www.w3.org/2001/XMLSchema-instance" i:type="EmptyRuleAction"></Action>`+
+			`</RuleDescription></content></entry>`)
+	})
+	model := AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+	existing := []AsbSubscriptionRule{readRule(t, az.RuleProperties{
+		Name:   "manual",
+		Filter: &az.SQLFilter{Expression: "sys.Label = 'manual'"},
+		Action: &az.SQLAction{Expression: "SET Origin = 'manual'"},
+	})}
+
+	err := client.RevertAsbRuleOperation(
+		context.Background(),
+		model,
+		AsbRuleOperation{Type: RULE_OPERATION_DELETE, RuleName: "manual"},
+		existing,
+	)
+
+	require.NoError(t, err)
+	assert.Contains(t, body, "sys.Label = &#39;manual&#39;")
+	assert.Contains(t, body, "SET Origin = &#39;manual&#39;", "the action is restored too")
+}
+
+func TestRevertAsbRuleOperation_FailsForRulesNotReadFromServiceBus(t *testing.T) {
+	client, requests := newRuleTestClient(t)
+	model := AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+
+	err := client.RevertAsbRuleOperation(
+		context.Background(),
+		model,
+		AsbRuleOperation{Type: RULE_OPERATION_UPDATE, RuleName: "Dg.Test.V1.Updated", Subscription: correlationSubscription("Dg.Test.V1.Updated")},
+		[]AsbSubscriptionRule{GetExpectedAsbSubscriptionRule(sqlSubscription("Dg.Test.V1.Updated"))},
+	)
+
+	assert.ErrorContains(t, err, "the rule was not read from Service Bus")
+	assert.Empty(t, requests())
+}
@@ -0,0 +1,738 @@
+package asb
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// This is a local evaluator for the subset of the Service Bus SQL filter grammar that is
+// used in practice: comparisons, LIKE, IN, IS NULL, EXISTS, AND/OR/NOT and basic arithmetic.
+// It follows the SQL three-valued logic, where a nil value represents unknown.
+// Functions like newid() are not supported and result in an error.
+
+type sqlFilterPropertyLookup func(name string, quoted bool) (interface{}, bool)
+
+type sqlFilterExpression func(lookup sqlFilterPropertyLookup) (interface{}, error)
+
+func evaluateSqlFilter(expression string, parameters map[string]interface{}, lookup sqlFilterPropertyLookup) (bool, error) {
+	parser, err := newSqlFilterParser(expression, parameters)
+	if err != nil {
+		return false, err
+	}
+
+	compiled, err := parser.parse()
+	if err != nil {
+		return false, err
+	}
+
+	value, err := compiled(lookup)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	return ok && result, nil
+}
+
+type sqlFilterTokenKind int
+
+const (
+	sqlTokenEnd sqlFilterTokenKind = iota
+	sqlTokenIdentifier
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenParameter
+	sqlTokenOperator
+)
+
+type sqlFilterToken struct {
+	kind   sqlFilterTokenKind
+	text   string
+	quoted bool
+}
+
+func (t sqlFilterToken) isKeyword(keyword string) bool {
+	return t.kind == sqlTokenIdentifier && !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+func (t sqlFilterToken) isOperator(operator string) bool {
+	return t.kind == sqlTokenOperator && t.text == operator
+}
+
+func tokenizeSqlFilter(expression string) ([]sqlFilterToken, error) {
+	tokens := []sqlFilterToken{}
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		current := runes[i]
+
+		switch {
+		case unicode.IsSpace(current):
+			i++
+		case current == '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated property name at position %d", i)
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenIdentifier, text: string(runes[i+1 : end]), quoted: true})
+			i = end + 1
+		case current == '\'':
+			var builder strings.Builder
+			end := i + 1
+			for {
+				if end >= len(runes) {
+					return nil, fmt.Errorf("unterminated string literal at position %d", i)
+				}
+				if runes[end] == '\'' {
+					// A doubled quote is an escaped quote
+					if end+1 < len(runes) && runes[end+1] == '\'' {
+						builder.WriteRune('\'')
+						end += 2
+						continue
+					}
+					break
+				}
+				builder.WriteRune(runes[end])
+				end++
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenString, text: builder.String()})
+			i = end + 1
+		case unicode.IsDigit(current) || (current == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenNumber, text: string(runes[i:end])})
+			i = end
+		case current == '@':
+			end := i + 1
+			for end < len(runes) && isSqlIdentifierRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenParameter, text: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(current) || current == '_':
+			end := i
+			for end < len(runes) && (isSqlIdentifierRune(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenIdentifier, text: string(runes[i:end])})
+			i = end
+		default:
+			operator := string(current)
+			if i+1 < len(runes) {
+				switch pair := string(runes[i : i+2]); pair {
+				case "<>", "!=", "<=", ">=":
+					operator = pair
+				}
+			}
+			if !strings.Contains("()=<>!+-*/%,", string(current)) || operator == "!" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", current, i)
+			}
+			tokens = append(tokens, sqlFilterToken{kind: sqlTokenOperator, text: operator})
+			i += len(operator)
+		}
+	}
+
+	return append(tokens, sqlFilterToken{kind: sqlTokenEnd}), nil
+}
+
+func isSqlIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+type sqlFilterParser struct {
+	tokens     []sqlFilterToken
+	position   int
+	parameters map[string]interface{}
+}
+
+func newSqlFilterParser(expression string, parameters map[string]interface{}) (*sqlFilterParser, error) {
+	tokens, err := tokenizeSqlFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlFilterParser{tokens: tokens, parameters: parameters}, nil
+}
+
+func (p *sqlFilterParser) peek() sqlFilterToken {
+	return p.tokens[p.position]
+}
+
+func (p *sqlFilterParser) next() sqlFilterToken {
+	token := p.tokens[p.position]
+	if token.kind != sqlTokenEnd {
+		p.position++
+	}
+	return token
+}
+
+func (p *sqlFilterParser) expectOperator(operator string) error {
+	if token := p.next(); !token.isOperator(operator) {
+		return fmt.Errorf("expected '%s' but found '%s'", operator, token.text)
+	}
+	return nil
+}
+
+func (p *sqlFilterParser) parse() (sqlFilterExpression, error) {
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.kind != sqlTokenEnd {
+		return nil, fmt.Errorf("unexpected token '%s'", token.text)
+	}
+
+	return expression, nil
+}
+
+func (p *sqlFilterParser) parseOr() (sqlFilterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = makeSqlLogicalExpression(left, right, true)
+	}
+
+	return left, nil
+}
+
+func (p *sqlFilterParser) parseAnd() (sqlFilterExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = makeSqlLogicalExpression(left, right, false)
+	}
+
+	return left, nil
+}
+
+func (p *sqlFilterParser) parseNot() (sqlFilterExpression, error) {
+	if !p.peek().isKeyword("NOT") {
+		return p.parsePredicate()
+	}
+
+	p.next()
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	return makeSqlNotExpression(operand), nil
+}
+
+func (p *sqlFilterParser) parsePredicate() (sqlFilterExpression, error) {
+	if p.peek().isKeyword("EXISTS") {
+		p.next()
+		if err := p.expectOperator("("); err != nil {
+			return nil, err
+		}
+		property := p.next()
+		if property.kind != sqlTokenIdentifier {
+			return nil, fmt.Errorf("EXISTS expects a property name but found '%s'", property.text)
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+			_, exists := lookup(property.text, property.quoted)
+			return exists, nil
+		}, nil
+	}
+
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	token := p.peek()
+	switch {
+	case token.isKeyword("IS"):
+		p.next()
+		negate := false
+		if p.peek().isKeyword("NOT") {
+			p.next()
+			negate = true
+		}
+		if !p.next().isKeyword("NULL") {
+			return nil, fmt.Errorf("expected NULL after IS")
+		}
+		return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+			value, err := left(lookup)
+			if err != nil {
+				return nil, err
+			}
+			return (value == nil) != negate, nil
+		}, nil
+	case token.isKeyword("NOT"):
+		p.next()
+		predicate, err := p.parseLikeOrIn(left)
+		if err != nil {
+			return nil, err
+		}
+		return makeSqlNotExpression(predicate), nil
+	case token.isKeyword("LIKE") || token.isKeyword("IN"):
+		return p.parseLikeOrIn(left)
+	case token.kind == sqlTokenOperator && isSqlComparisonOperator(token.text):
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return makeSqlComparisonExpression(token.text, left, right), nil
+	}
+
+	return left, nil
+}
+
+func (p *sqlFilterParser) parseLikeOrIn(left sqlFilterExpression) (sqlFilterExpression, error) {
+	token := p.next()
+
+	if token.isKeyword("LIKE") {
+		pattern := p.next()
+		if pattern.kind != sqlTokenString {
+			return nil, fmt.Errorf("LIKE expects a string pattern but found '%s'", pattern.text)
+		}
+
+		escape := ""
+		if p.peek().isKeyword("ESCAPE") {
+			p.next()
+			escapeToken := p.next()
+			if escapeToken.kind != sqlTokenString || len([]rune(escapeToken.text)) != 1 {
+				return nil, fmt.Errorf("ESCAPE expects a single character string")
+			}
+			escape = escapeToken.text
+		}
+
+		matcher, err := compileSqlLikePattern(pattern.text, escape)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+			value, err := left(lookup)
+			if err != nil {
+				return nil, err
+			}
+			stringValue, ok := value.(string)
+			if !ok {
+				return nil, nil
+			}
+			return matcher.MatchString(stringValue), nil
+		}, nil
+	}
+
+	if token.isKeyword("IN") {
+		if err := p.expectOperator("("); err != nil {
+			return nil, err
+		}
+
+		candidates := []sqlFilterExpression{}
+		for {
+			candidate, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, candidate)
+
+			if !p.peek().isOperator(",") {
+				break
+			}
+			p.next()
+		}
+
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+
+		return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+			value, err := left(lookup)
+			if err != nil || value == nil {
+				return nil, err
+			}
+			for _, candidate := range candidates {
+				candidateValue, err := candidate(lookup)
+				if err != nil {
+					return nil, err
+				}
+				if equal, ok := compareSqlValues(value, candidateValue); ok && equal == 0 {
+					return true, nil
+				}
+			}
+			return false, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("expected LIKE or IN but found '%s'", token.text)
+}
+
+func (p *sqlFilterParser) parseAdditive() (sqlFilterExpression, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isOperator("+") || p.peek().isOperator("-") {
+		operator := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = makeSqlArithmeticExpression(operator, left, right)
+	}
+
+	return left, nil
+}
+
+func (p *sqlFilterParser) parseMultiplicative() (sqlFilterExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isOperator("*") || p.peek().isOperator("/") || p.peek().isOperator("%") {
+		operator := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = makeSqlArithmeticExpression(operator, left, right)
+	}
+
+	return left, nil
+}
+
+func (p *sqlFilterParser) parseUnary() (sqlFilterExpression, error) {
+	if p.peek().isOperator("-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return makeSqlArithmeticExpression("*", makeSqlConstantExpression(float64(-1)), operand), nil
+	}
+
+	if p.peek().isOperator("+") {
+		p.next()
+		return p.parseUnary()
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *sqlFilterParser) parsePrimary() (sqlFilterExpression, error) {
+	token := p.next()
+
+	switch token.kind {
+	case sqlTokenString:
+		return makeSqlConstantExpression(token.text), nil
+	case sqlTokenNumber:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", token.text)
+		}
+		return makeSqlConstantExpression(number), nil
+	case sqlTokenParameter:
+		value, ok := p.parameters[token.text]
+		if !ok {
+			value, ok = p.parameters[strings.TrimPrefix(token.text, "@")]
+		}
+		if !ok {
+			return nil, fmt.Errorf("parameter '%s' is not defined", token.text)
+		}
+		return makeSqlConstantExpression(normalizeSqlValue(value)), nil
+	case sqlTokenOperator:
+		if token.text != "(" {
+			break
+		}
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case sqlTokenIdentifier:
+		switch {
+		case token.isKeyword("TRUE"):
+			return makeSqlConstantExpression(true), nil
+		case token.isKeyword("FALSE"):
+			return makeSqlConstantExpression(false), nil
+		case token.isKeyword("NULL"):
+			return makeSqlConstantExpression(nil), nil
+		}
+		if p.peek().isOperator("(") {
+			return nil, fmt.Errorf("function '%s' is not supported", token.text)
+		}
+		return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+			value, _ := lookup(token.text, token.quoted)
+			return normalizeSqlValue(value), nil
+		}, nil
+	case sqlTokenEnd:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected token '%s'", token.text)
+}
+
+func makeSqlConstantExpression(value interface{}) sqlFilterExpression {
+	return func(sqlFilterPropertyLookup) (interface{}, error) {
+		return value, nil
+	}
+}
+
+func makeSqlNotExpression(operand sqlFilterExpression) sqlFilterExpression {
+	return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+		value, err := operand(lookup)
+		if err != nil {
+			return nil, err
+		}
+		boolValue, ok := value.(bool)
+		if !ok {
+			return nil, nil
+		}
+		return !boolValue, nil
+	}
+}
+
+func makeSqlLogicalExpression(left, right sqlFilterExpression, isOr bool) sqlFilterExpression {
+	return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+		leftValue, err := left(lookup)
+		if err != nil {
+			return nil, err
+		}
+		rightValue, err := right(lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		leftBool, leftKnown := leftValue.(bool)
+		rightBool, rightKnown := rightValue.(bool)
+
+		// The short circuiting value decides the result, even if the other side is unknown
+		if (leftKnown && leftBool == isOr) || (rightKnown && rightBool == isOr) {
+			return isOr, nil
+		}
+		if !leftKnown || !rightKnown {
+			return nil, nil
+		}
+		return !isOr, nil
+	}
+}
+
+func isSqlComparisonOperator(operator string) bool {
+	switch operator {
+	case "=", "<>", "!=", "<", ">", "<=", ">=":
+		return true
+	}
+	return false
+}
+
+func makeSqlComparisonExpression(operator string, left, right sqlFilterExpression) sqlFilterExpression {
+	return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+		leftValue, err := left(lookup)
+		if err != nil {
+			return nil, err
+		}
+		rightValue, err := right(lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		comparison, ok := compareSqlValues(leftValue, rightValue)
+		if !ok {
+			return nil, nil
+		}
+
+		switch operator {
+		case "=":
+			return comparison == 0, nil
+		case "<>", "!=":
+			return comparison != 0, nil
+		case "<":
+			return comparison < 0, nil
+		case ">":
+			return comparison > 0, nil
+		case "<=":
+			return comparison <= 0, nil
+		default:
+			return comparison >= 0, nil
+		}
+	}
+}
+
+func makeSqlArithmeticExpression(operator string, left, right sqlFilterExpression) sqlFilterExpression {
+	return func(lookup sqlFilterPropertyLookup) (interface{}, error) {
+		leftValue, err := left(lookup)
+		if err != nil {
+			return nil, err
+		}
+		rightValue, err := right(lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		leftNumber, leftOk := toSqlNumber(leftValue)
+		rightNumber, rightOk := toSqlNumber(rightValue)
+		if !leftOk || !rightOk {
+			return nil, nil
+		}
+
+		switch operator {
+		case "+":
+			return leftNumber + rightNumber, nil
+		case "-":
+			return leftNumber - rightNumber, nil
+		case "*":
+			return leftNumber * rightNumber, nil
+		case "/":
+			if rightNumber == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return leftNumber / rightNumber, nil
+		default:
+			if rightNumber == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return math.Mod(leftNumber, rightNumber), nil
+		}
+	}
+}
+
+// compareSqlValues returns -1, 0 or 1 and whether the values were comparable at all.
+// Message properties are only known as strings, so they are coerced to numbers or booleans
+// when compared against such a literal.
+func compareSqlValues(left, right interface{}) (int, bool) {
+	if left == nil || right == nil {
+		return 0, false
+	}
+
+	leftString, leftIsString := left.(string)
+	rightString, rightIsString := right.(string)
+	if leftIsString && rightIsString {
+		return strings.Compare(leftString, rightString), true
+	}
+
+	leftBool, leftIsBool := left.(bool)
+	rightBool, rightIsBool := right.(bool)
+	if leftIsBool || rightIsBool {
+		if !leftIsBool {
+			leftBool, leftIsBool = parseSqlBool(left)
+		}
+		if !rightIsBool {
+			rightBool, rightIsBool = parseSqlBool(right)
+		}
+		if !leftIsBool || !rightIsBool {
+			return 0, false
+		}
+		if leftBool == rightBool {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	leftNumber, leftOk := toSqlNumber(left)
+	rightNumber, rightOk := toSqlNumber(right)
+	if !leftOk || !rightOk {
+		return 0, false
+	}
+
+	switch {
+	case leftNumber < rightNumber:
+		return -1, true
+	case leftNumber > rightNumber:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+func parseSqlBool(value interface{}) (bool, bool) {
+	stringValue, ok := value.(string)
+	if !ok {
+		return false, false
+	}
+	parsed, err := strconv.ParseBool(stringValue)
+	return parsed, err == nil
+}
+
+func toSqlNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case string:
+		parsed, err := strconv.ParseFloat(typed, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+func normalizeSqlValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed)
+	case int32:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case float32:
+		return float64(typed)
+	}
+	return value
+}
+
+func compileSqlLikePattern(pattern string, escape string) (*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString("(?s)^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		current := string(runes[i])
+
+		if escape != "" && current == escape {
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("LIKE pattern ends with the escape character")
+			}
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(runes[i])))
+			continue
+		}
+
+		switch current {
+		case "%":
+			builder.WriteString(".*")
+		case "_":
+			builder.WriteString(".")
+		default:
+			builder.WriteString(regexp.QuoteMeta(current))
+		}
+	}
+
+	builder.WriteString("$")
+	return regexp.Compile(builder.String())
+}
@@ -0,0 +1,93 @@
+package asb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const NSERVICEBUS_ENCLOSED_MESSAGE_TYPES_HEADER = "NServiceBus.EnclosedMessageTypes"
+
+type AsbMessageTypeSubscriber struct {
+	TopicName    string
+	EndpointName string
+	ForwardTo    *string
+	Rule         AsbSubscriptionRule
+}
+
+// AsbUnevaluatedRule is a rule, whose filter could not be evaluated locally, so it is unknown whether it routes the message.
+type AsbUnevaluatedRule struct {
+	TopicName    string
+	EndpointName string
+	RuleName     string
+	FilterType   string
+	Reason       string
+}
+
+// GetMessageTypeSubscribers returns every subscription rule on the topic, which would route
+// a message of the given type. The message is simulated with the headers NServiceBus and the
+// correlation filters of this provider use to identify the message type. Rules, which could not be evaluated,
+// are returned separately, as their subscriptions might route the message type as well.
+func (w *AsbClientWrapper) GetMessageTypeSubscribers(
+	ctx context.Context,
+	topicName string,
+	messageTypeFullName string,
+) ([]AsbMessageTypeSubscriber, []AsbUnevaluatedRule, error) {
+	message := AsbMessage{
+		ApplicationProperties: map[string]string{
+			NSERVICEBUS_ENCLOSED_MESSAGE_TYPES_HEADER: messageTypeFullName,
+			CORRELATIONFILTER_HEADER:                  messageTypeFullName,
+		},
+	}
+
+	subscriptions, err := w.GetTopicSubscriptions(ctx, topicName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subscribers := []AsbMessageTypeSubscriber{}
+	unevaluatedRules := []AsbUnevaluatedRule{}
+	for _, subscription := range subscriptions {
+		rules, err := w.GetSubscriptionRules(ctx, topicName, subscription.SubscriptionName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, rule := range rules {
+			matches, err := EvaluateRuleFilter(rule.Filter, message)
+			if err != nil {
+				tflog.Info(ctx, fmt.Sprintf("Rule %s of subscription %s could not be evaluated: %s", rule.Name, subscription.SubscriptionName, err.Error()))
+				unevaluatedRules = append(unevaluatedRules, AsbUnevaluatedRule{
+					TopicName:    topicName,
+					EndpointName: subscription.SubscriptionName,
+					RuleName:     rule.Name,
+					FilterType:   GetRuleFilterType(rule.Filter),
+					Reason:       err.Error(),
+				})
+				continue
+			}
+			if !matches {
+				continue
+			}
+
+			decodedRule, err := convertToAsbSubscriptionRule(rule)
+			if err != nil {
+				// Rules like a true filter route every message type, but have no filter value
+				decodedRule = &AsbSubscriptionRule{
+					Name:       rule.Name,
+					FilterType: GetRuleFilterType(rule.Filter),
+				}
+			}
+
+			subscribers = append(subscribers, AsbMessageTypeSubscriber{
+				TopicName:    topicName,
+				EndpointName: subscription.SubscriptionName,
+				ForwardTo:    subscription.ForwardTo,
+				Rule:         *decodedRule,
+			})
+		}
+	}
+
+	return subscribers, unevaluatedRules, nil
+}
@@ -0,0 +1,91 @@
+package asb
+
+import (
+	"context"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+func (w *AsbClientWrapper) GetTopics(ctx context.Context) ([]az.TopicItem, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Listing topics",
+		func() ([]az.TopicItem, error) {
+			topics := []az.TopicItem{}
+			pager := w.Client.NewListTopicsPager(nil)
+
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				topics = append(topics, page.Topics...)
+			}
+
+			return topics, nil
+		},
+	)
+}
+
+func (w *AsbClientWrapper) GetTopicSubscriptions(
+	ctx context.Context,
+	topicName string,
+) ([]az.SubscriptionPropertiesItem, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Listing subscriptions of topic "+topicName,
+		func() ([]az.SubscriptionPropertiesItem, error) {
+			subscriptions := []az.SubscriptionPropertiesItem{}
+			pager := w.Client.NewListSubscriptionsPager(topicName, nil)
+
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				subscriptions = append(subscriptions, page.Subscriptions...)
+			}
+
+			return subscriptions, nil
+		},
+	)
+}
+
+func (w *AsbClientWrapper) GetSubscriptionRules(
+	ctx context.Context,
+	topicName string,
+	subscriptionName string,
+) ([]az.RuleProperties, error) {
+	return w.getCachedSubscriptionRules(ctx, topicName, subscriptionName)
+}
+
+func (w *AsbClientWrapper) listSubscriptionRules(
+	ctx context.Context,
+	topicName string,
+	subscriptionName string,
+) ([]az.RuleProperties, error) {
+	return runWithRetry(
+		ctx,
+		w.retryOptions(),
+		"Listing rules of subscription "+subscriptionName,
+		func() ([]az.RuleProperties, error) {
+			rules := []az.RuleProperties{}
+			pager := w.Client.NewListRulesPager(topicName, subscriptionName, nil)
+
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				rules = append(rules, page.Rules...)
+			}
+
+			return rules, nil
+		},
+	)
+}
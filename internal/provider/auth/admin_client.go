@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+)
+
+// AdminClientFactory creates admin clients for namespaces, which share the credential of the provider.
+type AdminClientFactory struct {
+	Method AuthMethod
+
+	config     AuthConfig
+	options    az.ClientOptions
+	credential azcore.TokenCredential // Not set for shared access methods
+}
+
+// NewAdminClientFactory creates the credential of the method returned by GetAuthMethod. The credential requests
+// its tokens with the transport of the options, unless the config has its own.
+func NewAdminClientFactory(c AuthConfig, options az.ClientOptions) (*AdminClientFactory, error) {
+	if c.Transport == nil {
+		c.Transport = options.Transport
+	}
+
+	method, err := c.GetAuthMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	factory := &AdminClientFactory{
+		Method:  method,
+		config:  c,
+		options: options,
+	}
+
+	if method == AUTH_METHOD_CONNECTION_STRING || method == AUTH_METHOD_SHARED_ACCESS_KEY {
+		return factory, nil
+	}
+
+	if c.UseDevelopmentEmulator {
+		return nil, fmt.Errorf("'use_development_emulator' requires 'connection_string' or a shared access key, the emulator does not support Entra ID")
+	}
+
+	factory.credential, _, err = NewCredential(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
+// NewAdminClient creates the admin client for the namespace at hostname. A connection string is only valid for its own namespace.
+func (f *AdminClientFactory) NewAdminClient(hostname string) (*az.Client, error) {
+	if f.credential != nil {
+		return az.NewClient(hostname, newAudienceCredential(f.credential, f.config.Cloud.getAudience(hostname)), &f.options)
+	}
+
+	connectionString := f.config.ConnectionString
+	if f.Method == AUTH_METHOD_SHARED_ACCESS_KEY {
+		connectionString = fmt.Sprintf("Endpoint=sb://%s/;SharedAccessKeyName=%s;SharedAccessKey=%s", hostname, f.config.SharedAccessKeyName, f.config.SharedAccessKey)
+	} else if endpointHostname, err := f.config.GetHostname(""); err != nil || !strings.EqualFold(endpointHostname, hostname) {
+		return nil, fmt.Errorf("the connection string is only valid for the namespace %s, use a shared access key or Entra ID to manage other namespaces", endpointHostname)
+	}
+
+	// Copied, such that the policies of other clients are not changed
+	options := f.options
+	options.PerCallPolicies = append([]policy.Policy{}, f.options.PerCallPolicies...)
+	if f.config.UseDevelopmentEmulator || isDevelopmentEmulatorConnectionString(connectionString) {
+		options.PerCallPolicies = append(options.PerCallPolicies, plainHttpPolicy{})
+	}
+
+	return az.NewClientFromConnectionString(connectionString, &options)
+}
+
+// NewAdminClient creates the admin client for the namespace at hostname, authenticated with the method returned by GetAuthMethod.
+func NewAdminClient(c AuthConfig, hostname string, options az.ClientOptions) (*az.Client, AuthMethod, error) {
+	factory, err := NewAdminClientFactory(c, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := factory.NewAdminClient(hostname)
+	return client, factory.Method, err
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminClient_DevelopmentEmulatorUsesPlainHttp(t *testing.T) {
+	requestedUrls := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedUrls <- r.URL.Path
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	config := AuthConfig{
+		ConnectionString: "Endpoint=sb://" + host + ";SharedAccessKeyName=RootManageSharedAccessKey;SharedAccessKey=SAS_KEY_VALUE;UseDevelopmentEmulator=true;",
+	}
+
+	client, method, err := NewAdminClient(config, host, az.ClientOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, AUTH_METHOD_CONNECTION_STRING, method)
+
+	queue, err := client.GetQueue(context.Background(), "my-queue", nil)
+	require.NoError(t, err)
+	assert.Nil(t, queue)
+	assert.Equal(t, "/my-queue", <-requestedUrls)
+}
+
+func TestNewAdminClient_DevelopmentEmulatorRequiresSharedAccess(t *testing.T) {
+	_, _, err := NewAdminClient(AuthConfig{UseDevelopmentEmulator: true}, "localhost", az.ClientOptions{})
+
+	assert.ErrorContains(t, err, "'use_development_emulator' requires")
+}
+
+func TestAdminClientFactory_ConnectionStringOnlyForItsNamespace(t *testing.T) {
+	factory, err := NewAdminClientFactory(AuthConfig{
+		ConnectionString: "Endpoint=sb://my-namespace.servicebus.windows.net/;SharedAccessKeyName=Root;SharedAccessKey=key",
+	}, az.ClientOptions{})
+	require.NoError(t, err)
+
+	_, err = factory.NewAdminClient("my-namespace.servicebus.windows.net")
+	assert.NoError(t, err)
+
+	_, err = factory.NewAdminClient("other.servicebus.windows.net")
+	assert.ErrorContains(t, err, "only valid for the namespace my-namespace.servicebus.windows.net")
+}
+
+func TestAdminClientFactory_SharedAccessKeyForAnyNamespace(t *testing.T) {
+	factory, err := NewAdminClientFactory(AuthConfig{SharedAccessKeyName: "Root", SharedAccessKey: "key"}, az.ClientOptions{})
+	require.NoError(t, err)
+
+	_, err = factory.NewAdminClient("other.servicebus.windows.net")
+	assert.NoError(t, err)
+}
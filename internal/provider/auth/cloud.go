@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const DEFAULT_CLOUD_ENVIRONMENT = "public"
+
+// CloudEnvironment contains the endpoints of an Azure cloud.
+type CloudEnvironment struct {
+	Name           string
+	AuthorityHost  string // The base url of Entra ID
+	EndpointSuffix string // The suffix of the hostnames of the namespaces, without leading dot
+	Audience       string // The resource to request tokens for, the namespace itself when empty
+}
+
+var cloudEnvironments = map[string]CloudEnvironment{
+	"public": {
+		Name:           "public",
+		AuthorityHost:  cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+		EndpointSuffix: "servicebus.windows.net",
+		Audience:       "https://servicebus.azure.net",
+	},
+	"usgovernment": {
+		Name:           "usgovernment",
+		AuthorityHost:  cloud.AzureGovernment.ActiveDirectoryAuthorityHost,
+		EndpointSuffix: "servicebus.usgovcloudapi.net",
+		Audience:       "https://servicebus.usgovcloudapi.net",
+	},
+	"china": {
+		Name:           "china",
+		AuthorityHost:  cloud.AzureChina.ActiveDirectoryAuthorityHost,
+		EndpointSuffix: "servicebus.chinacloudapi.cn",
+		Audience:       "https://servicebus.chinacloudapi.cn",
+	},
+}
+
+// The audience the admin client requests tokens for, independent of the cloud.
+var publicCloudAudience = cloudEnvironments[DEFAULT_CLOUD_ENVIRONMENT].Audience
+
+func GetCloudEnvironmentNames() []string {
+	names := make([]string, 0, len(cloudEnvironments))
+	for name := range cloudEnvironments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetCloudEnvironment returns the named environment, the public cloud when name is empty, with the endpoint suffix
+// and authority host replaced when set. Tokens for a custom endpoint suffix are requested for the namespace itself.
+func GetCloudEnvironment(name string, endpointSuffix string, authorityHost string) (CloudEnvironment, error) {
+	if name == "" {
+		name = DEFAULT_CLOUD_ENVIRONMENT
+	}
+
+	environment, ok := cloudEnvironments[strings.ToLower(name)]
+	if !ok {
+		return CloudEnvironment{}, fmt.Errorf("unknown environment %q, expected one of %s", name, strings.Join(GetCloudEnvironmentNames(), ", "))
+	}
+
+	endpointSuffix = strings.Trim(strings.ToLower(endpointSuffix), ".")
+	if endpointSuffix != "" && endpointSuffix != environment.EndpointSuffix {
+		environment.EndpointSuffix = endpointSuffix
+		environment.Audience = ""
+	}
+
+	if authorityHost != "" {
+		if !strings.HasPrefix(authorityHost, "https://") {
+			return CloudEnvironment{}, fmt.Errorf("the authority host %q must be an https url", authorityHost)
+		}
+		environment.AuthorityHost = strings.TrimSuffix(authorityHost, "/") + "/"
+	}
+
+	return environment, nil
+}
+
+func (e CloudEnvironment) clientOptions() azcore.ClientOptions {
+	if e.AuthorityHost == "" {
+		return azcore.ClientOptions{}
+	}
+
+	return azcore.ClientOptions{
+		Cloud: cloud.Configuration{
+			ActiveDirectoryAuthorityHost: e.AuthorityHost,
+			Services:                     map[cloud.ServiceName]cloud.ServiceConfiguration{},
+		},
+	}
+}
+
+// getAudience returns the resource to request tokens for the namespace at hostname.
+func (e CloudEnvironment) getAudience(hostname string) string {
+	if e.Audience != "" {
+		return e.Audience
+	}
+
+	if e.EndpointSuffix == "" {
+		return publicCloudAudience
+	}
+
+	return "https://" + hostname
+}
+
+// audienceCredential requests tokens for the audience of the cloud, as the admin client always requests
+// tokens for the audience of the public cloud.
+type audienceCredential struct {
+	credential azcore.TokenCredential
+	scope      string
+}
+
+func newAudienceCredential(credential azcore.TokenCredential, audience string) azcore.TokenCredential {
+	if audience == publicCloudAudience {
+		return credential
+	}
+
+	return audienceCredential{
+		credential: credential,
+		scope:      strings.TrimSuffix(audience, "/") + "/.default",
+	}
+}
+
+func (c audienceCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	options.Scopes = []string{c.scope}
+	return c.credential.GetToken(ctx, options)
+}
+
+// CheckHostname returns an error, when the hostname belongs to another known cloud than the environment.
+func (e CloudEnvironment) CheckHostname(hostname string) error {
+	hostname = strings.ToLower(hostname)
+	if strings.HasSuffix(hostname, "."+e.EndpointSuffix) {
+		return nil
+	}
+
+	for _, name := range GetCloudEnvironmentNames() {
+		other := cloudEnvironments[name]
+		if strings.HasSuffix(hostname, "."+other.EndpointSuffix) {
+			return fmt.Errorf("the hostname %s belongs to the %s cloud, but the environment is %s with the endpoint suffix %s. "+
+				"Set 'environment' to %q", hostname, other.Name, e.Name, e.EndpointSuffix, other.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scopeRecordingCredential struct {
+	scopes []string
+}
+
+func (c *scopeRecordingCredential) GetToken(_ context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.scopes = options.Scopes
+	return azcore.AccessToken{Token: "token"}, nil
+}
+
+func TestGetCloudEnvironment_Defaults(t *testing.T) {
+	environment, err := GetCloudEnvironment("", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "public", environment.Name)
+	assert.Equal(t, "https://login.microsoftonline.com/", environment.AuthorityHost)
+	assert.Equal(t, "servicebus.windows.net", environment.EndpointSuffix)
+	assert.Equal(t, publicCloudAudience, environment.getAudience("my-namespace.servicebus.windows.net"))
+}
+
+func TestGetCloudEnvironment_China(t *testing.T) {
+	environment, err := GetCloudEnvironment("China", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://login.chinacloudapi.cn/", environment.AuthorityHost)
+	assert.Equal(t, "servicebus.chinacloudapi.cn", environment.EndpointSuffix)
+	assert.Equal(t, "https://servicebus.chinacloudapi.cn", environment.getAudience("my-namespace.servicebus.chinacloudapi.cn"))
+}
+
+func TestGetCloudEnvironment_CustomEndpoints(t *testing.T) {
+	environment, err := GetCloudEnvironment("", ".ServiceBus.Example.Cloud", "https://login.example.cloud")
+
+	require.NoError(t, err)
+	assert.Equal(t, "servicebus.example.cloud", environment.EndpointSuffix)
+	assert.Equal(t, "https://login.example.cloud/", environment.AuthorityHost)
+	assert.Equal(t, "https://my-namespace.servicebus.example.cloud", environment.getAudience("my-namespace.servicebus.example.cloud"))
+}
+
+func TestGetCloudEnvironment_Errors(t *testing.T) {
+	_, err := GetCloudEnvironment("germany", "", "")
+	assert.ErrorContains(t, err, "expected one of china, public, usgovernment")
+
+	_, err = GetCloudEnvironment("", "", "login.example.cloud")
+	assert.ErrorContains(t, err, "must be an https url")
+}
+
+func TestAudienceCredential_ReplacesScope(t *testing.T) {
+	inner := &scopeRecordingCredential{}
+
+	credential := newAudienceCredential(inner, "https://servicebus.usgovcloudapi.net")
+	_, err := credential.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{"https://servicebus.azure.net//.default"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://servicebus.usgovcloudapi.net/.default"}, inner.scopes)
+}
+
+func TestAudienceCredential_PublicCloudUnchanged(t *testing.T) {
+	inner := &scopeRecordingCredential{}
+
+	assert.Same(t, inner, newAudienceCredential(inner, publicCloudAudience))
+}
+
+func TestCheckHostname(t *testing.T) {
+	public, _ := GetCloudEnvironment("public", "", "")
+
+	assert.NoError(t, public.CheckHostname("my-namespace.servicebus.windows.net"))
+	assert.NoError(t, public.CheckHostname("localhost:5300"))
+	assert.ErrorContains(t, public.CheckHostname("my-namespace.servicebus.chinacloudapi.cn"), `Set 'environment' to "china"`)
+}
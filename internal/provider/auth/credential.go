@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+type AuthMethod string
+
+const (
+	AUTH_METHOD_CONNECTION_STRING  AuthMethod = "connection_string"
+	AUTH_METHOD_SHARED_ACCESS_KEY  AuthMethod = "shared_access_key"
+	AUTH_METHOD_OIDC               AuthMethod = "oidc"
+	AUTH_METHOD_MANAGED_IDENTITY   AuthMethod = "managed_identity"
+	AUTH_METHOD_CLIENT_CERTIFICATE AuthMethod = "client_certificate"
+	AUTH_METHOD_CLIENT_SECRET      AuthMethod = "client_secret"
+	AUTH_METHOD_DEFAULT            AuthMethod = "default"
+)
+
+// AuthConfig contains the resolved authentication settings of the provider, after environment variables are applied.
+type AuthConfig struct {
+	TenantId string
+	ClientId string // For managed identities the client id of a user-assigned identity
+
+	ClientSecret              string
+	ClientCertificatePath     string
+	ClientCertificatePassword string
+
+	UseOidc           bool
+	OidcToken         string
+	OidcTokenFilePath string
+	OidcRequestUrl    string
+	OidcRequestToken  string
+
+	UseMsi bool
+
+	ConnectionString       string
+	SharedAccessKeyName    string
+	SharedAccessKey        string
+	UseDevelopmentEmulator bool // Sends management requests over plain HTTP, also enabled by UseDevelopmentEmulator=true in the connection string
+
+	Cloud CloudEnvironment // The public cloud, when not set
+
+	Transport policy.Transporter // Sends the token requests, the default HTTP client when not set
+}
+
+// GetAuthMethod returns the method used to authenticate, in order of precedence: connection string,
+// shared access key, OIDC, managed identity, client certificate, client secret and finally the default credential.
+// An error is returned, when the settings of the selected method are incomplete.
+func (c AuthConfig) GetAuthMethod() (AuthMethod, error) {
+	if c.UseOidc && c.UseMsi {
+		return "", fmt.Errorf("'use_oidc' and 'use_msi' cannot both be enabled, choose one way to authenticate")
+	}
+
+	if c.ConnectionString != "" || c.SharedAccessKeyName != "" || c.SharedAccessKey != "" {
+		return c.getSharedAccessAuthMethod()
+	}
+
+	if c.UseOidc {
+		if err := c.requireServicePrincipal("use_oidc"); err != nil {
+			return "", err
+		}
+		if c.OidcToken == "" && c.OidcTokenFilePath == "" && (c.OidcRequestUrl == "" || c.OidcRequestToken == "") {
+			return "", fmt.Errorf("'use_oidc' requires a token source: set 'oidc_token', 'oidc_token_file_path', " +
+				"or both 'oidc_request_url' and 'oidc_request_token', which are set automatically in GitHub Actions with the 'id-token: write' permission")
+		}
+
+		return AUTH_METHOD_OIDC, nil
+	}
+
+	if c.UseMsi {
+		return AUTH_METHOD_MANAGED_IDENTITY, nil
+	}
+
+	if c.ClientCertificatePath != "" {
+		if err := c.requireServicePrincipal("client_certificate_path"); err != nil {
+			return "", err
+		}
+
+		return AUTH_METHOD_CLIENT_CERTIFICATE, nil
+	}
+
+	if c.ClientSecret != "" {
+		if err := c.requireServicePrincipal("client_secret"); err != nil {
+			return "", err
+		}
+
+		return AUTH_METHOD_CLIENT_SECRET, nil
+	}
+
+	return AUTH_METHOD_DEFAULT, nil
+}
+
+// NewCredential creates the credential of the method returned by GetAuthMethod.
+func NewCredential(c AuthConfig) (azcore.TokenCredential, AuthMethod, error) {
+	method, err := c.GetAuthMethod()
+	if err != nil {
+		return nil, "", err
+	}
+
+	clientOptions := c.Cloud.clientOptions()
+	clientOptions.Transport = c.Transport
+
+	var credential azcore.TokenCredential
+	switch method {
+	case AUTH_METHOD_CONNECTION_STRING, AUTH_METHOD_SHARED_ACCESS_KEY:
+		err = fmt.Errorf("%s authenticates without a token credential, use NewAdminClient", method)
+	case AUTH_METHOD_OIDC:
+		credential, err = azidentity.NewClientAssertionCredential(c.TenantId, c.ClientId, c.getOidcAssertion, &azidentity.ClientAssertionCredentialOptions{
+			ClientOptions: clientOptions,
+		})
+	case AUTH_METHOD_MANAGED_IDENTITY:
+		// The local identity endpoint must not be reached through the proxy of the transport
+		options := &azidentity.ManagedIdentityCredentialOptions{}
+		if c.ClientId != "" {
+			options.ID = azidentity.ClientID(c.ClientId)
+		}
+		credential, err = azidentity.NewManagedIdentityCredential(options)
+	case AUTH_METHOD_CLIENT_CERTIFICATE:
+		credential, err = c.newClientCertificateCredential(clientOptions)
+	case AUTH_METHOD_CLIENT_SECRET:
+		credential, err = azidentity.NewClientSecretCredential(c.TenantId, c.ClientId, c.ClientSecret, &azidentity.ClientSecretCredentialOptions{
+			ClientOptions: clientOptions,
+		})
+	default:
+		credential, err = azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: clientOptions,
+		})
+	}
+
+	return credential, method, err
+}
+
+func (c AuthConfig) requireServicePrincipal(setting string) error {
+	var missing []string
+	if c.TenantId == "" {
+		missing = append(missing, "'tenant_id'")
+	}
+	if c.ClientId == "" {
+		missing = append(missing, "'client_id'")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("'%s' is set, but %s of the service principal is missing", setting, strings.Join(missing, " and "))
+	}
+
+	return nil
+}
+
+func (c AuthConfig) newClientCertificateCredential(clientOptions azcore.ClientOptions) (azcore.TokenCredential, error) {
+	certificateData, err := os.ReadFile(c.ClientCertificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the client certificate: %w", err)
+	}
+
+	var password []byte
+	if c.ClientCertificatePassword != "" {
+		password = []byte(c.ClientCertificatePassword)
+	}
+
+	certificates, key, err := azidentity.ParseCertificates(certificateData, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the client certificate %s: %w", c.ClientCertificatePath, err)
+	}
+
+	return azidentity.NewClientCertificateCredential(c.TenantId, c.ClientId, certificates, key, &azidentity.ClientCertificateCredentialOptions{
+		ClientOptions: clientOptions,
+	})
+}
+
+// getOidcAssertion is called for every new access token, such that rotated token files
+// and short-lived tokens of the request url are picked up.
+func (c AuthConfig) getOidcAssertion(ctx context.Context) (string, error) {
+	if c.OidcToken != "" {
+		return c.OidcToken, nil
+	}
+
+	if c.OidcTokenFilePath != "" {
+		token, err := os.ReadFile(c.OidcTokenFilePath)
+		if err != nil {
+			return "", fmt.Errorf("could not read the OIDC token file: %w", err)
+		}
+
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	return requestOidcToken(ctx, c.Transport, c.OidcRequestUrl, c.OidcRequestToken)
+}
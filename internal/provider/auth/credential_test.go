@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAuthMethod_Precedence(t *testing.T) {
+	servicePrincipal := AuthConfig{TenantId: "tenant", ClientId: "client"}
+
+	cases := []struct {
+		name     string
+		modify   func(c *AuthConfig)
+		expected AuthMethod
+	}{
+		{"nothing set", func(c *AuthConfig) { *c = AuthConfig{} }, AUTH_METHOD_DEFAULT},
+		{"tenant and client only", func(c *AuthConfig) {}, AUTH_METHOD_DEFAULT},
+		{"client secret", func(c *AuthConfig) { c.ClientSecret = "secret" }, AUTH_METHOD_CLIENT_SECRET},
+		{"certificate over secret", func(c *AuthConfig) {
+			c.ClientSecret = "secret"
+			c.ClientCertificatePath = "cert.pfx"
+		}, AUTH_METHOD_CLIENT_CERTIFICATE},
+		{"msi over secret", func(c *AuthConfig) {
+			c.ClientSecret = "secret"
+			c.UseMsi = true
+		}, AUTH_METHOD_MANAGED_IDENTITY},
+		{"oidc over secret", func(c *AuthConfig) {
+			c.ClientSecret = "secret"
+			c.UseOidc = true
+			c.OidcToken = "token"
+		}, AUTH_METHOD_OIDC},
+	}
+
+	for _, testCase := range cases {
+		config := servicePrincipal
+		testCase.modify(&config)
+
+		method, err := config.GetAuthMethod()
+
+		require.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, method, testCase.name)
+	}
+}
+
+func TestGetAuthMethod_ManagedIdentityWithoutClientId(t *testing.T) {
+	method, err := AuthConfig{UseMsi: true}.GetAuthMethod()
+
+	require.NoError(t, err)
+	assert.Equal(t, AUTH_METHOD_MANAGED_IDENTITY, method)
+}
+
+func TestGetAuthMethod_Errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   AuthConfig
+		expected string
+	}{
+		{"oidc and msi", AuthConfig{UseOidc: true, UseMsi: true}, "cannot both be enabled"},
+		{"oidc without service principal", AuthConfig{UseOidc: true, OidcToken: "token"}, "'tenant_id' and 'client_id'"},
+		{"oidc without token source", AuthConfig{UseOidc: true, TenantId: "tenant", ClientId: "client"}, "requires a token source"},
+		{"oidc with request url only", AuthConfig{UseOidc: true, TenantId: "tenant", ClientId: "client", OidcRequestUrl: "https://token"}, "requires a token source"},
+		{"secret without tenant", AuthConfig{ClientId: "client", ClientSecret: "secret"}, "'client_secret' is set, but 'tenant_id'"},
+		{"certificate without client", AuthConfig{TenantId: "tenant", ClientCertificatePath: "cert.pfx"}, "'client_certificate_path' is set, but 'client_id'"},
+	}
+
+	for _, testCase := range cases {
+		_, err := testCase.config.GetAuthMethod()
+
+		require.Error(t, err, testCase.name)
+		assert.Contains(t, err.Error(), testCase.expected, testCase.name)
+	}
+}
+
+func TestGetOidcAssertion_TokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("first\n"), 0600))
+	config := AuthConfig{OidcTokenFilePath: tokenFile}
+
+	token, err := config.getOidcAssertion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", token)
+
+	// Rotated tokens are picked up
+	require.NoError(t, os.WriteFile(tokenFile, []byte("second"), 0600))
+	token, err = config.getOidcAssertion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second", token)
+}
+
+func TestGetOidcAssertion_TokenTakesPrecedence(t *testing.T) {
+	config := AuthConfig{OidcToken: "token", OidcTokenFilePath: "does-not-exist"}
+
+	token, err := config.getOidcAssertion(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
+
+func TestGetOidcAssertion_RequestUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer request-token", r.Header.Get("Authorization"))
+		assert.Equal(t, OIDC_AUDIENCE, r.URL.Query().Get("audience"))
+		assert.Equal(t, "1", r.URL.Query().Get("api-version"))
+		_, _ = w.Write([]byte(`{"value":"id-token"}`))
+	}))
+	defer server.Close()
+
+	config := AuthConfig{OidcRequestUrl: server.URL + "?api-version=1", OidcRequestToken: "request-token"}
+
+	token, err := config.getOidcAssertion(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "id-token", token)
+}
+
+func TestGetOidcAssertion_RequestUrlFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("bad token"))
+	}))
+	defer server.Close()
+
+	config := AuthConfig{OidcRequestUrl: server.URL, OidcRequestToken: "request-token"}
+
+	_, err := config.getOidcAssertion(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401: bad token")
+}
+
+func TestGetAuthMethod_SharedAccess(t *testing.T) {
+	method, err := AuthConfig{ConnectionString: "Endpoint=sb://localhost/", ClientSecret: "secret"}.GetAuthMethod()
+	require.NoError(t, err)
+	assert.Equal(t, AUTH_METHOD_CONNECTION_STRING, method)
+
+	method, err = AuthConfig{SharedAccessKeyName: "RootManageSharedAccessKey", SharedAccessKey: "key"}.GetAuthMethod()
+	require.NoError(t, err)
+	assert.Equal(t, AUTH_METHOD_SHARED_ACCESS_KEY, method)
+
+	_, err = AuthConfig{SharedAccessKeyName: "RootManageSharedAccessKey"}.GetAuthMethod()
+	assert.ErrorContains(t, err, "must be set together")
+
+	_, err = AuthConfig{ConnectionString: "Endpoint=sb://localhost/", SharedAccessKey: "key"}.GetAuthMethod()
+	assert.ErrorContains(t, err, "cannot be combined with 'shared_access_key_name'")
+
+	_, err = AuthConfig{ConnectionString: "Endpoint=sb://localhost/", UseMsi: true}.GetAuthMethod()
+	assert.ErrorContains(t, err, "cannot be combined with 'use_oidc' or 'use_msi'")
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var hostnameRegex = regexp.MustCompile(`(?i)^[a-z0-9]([a-z0-9.-]*[a-z0-9])?(:[0-9]+)?$`)
+
+// GetHostname returns the normalised hostname of the namespace, which is taken from the endpoint of the connection string, if one is set.
+func (c AuthConfig) GetHostname(hostname string) (string, error) {
+	if hostname != "" {
+		normalised, err := NormalizeHostname(hostname, c.Cloud.EndpointSuffix)
+		if err != nil {
+			return "", err
+		}
+		hostname = normalised
+	}
+
+	if c.ConnectionString == "" {
+		if hostname == "" {
+			return "", fmt.Errorf("'azure_servicebus_hostname' or the DG_SERVICEBUS_HOSTNAME environment variable is required, unless 'connection_string' is set")
+		}
+
+		return hostname, nil
+	}
+
+	endpoint, err := url.Parse(getConnectionStringValue(c.ConnectionString, "Endpoint"))
+	if err != nil || endpoint.Host == "" {
+		return "", fmt.Errorf("the connection string does not contain a valid Endpoint, expected for example 'Endpoint=sb://my-namespace.servicebus.windows.net/'")
+	}
+
+	if hostname != "" && !strings.EqualFold(hostname, endpoint.Host) {
+		return "", fmt.Errorf("'azure_servicebus_hostname' %s does not match the endpoint %s of the connection string", hostname, endpoint.Host)
+	}
+
+	return endpoint.Host, nil
+}
+
+// NormalizeHostname accepts a bare namespace name, a fully qualified hostname, or an sb:// or https:// url
+// and returns the hostname in its configured case, as it is shown in the outputs. Hostnames are compared
+// case-insensitively. Bare names are suffixed with the endpoint suffix of the cloud.
+func NormalizeHostname(hostname string, endpointSuffix string) (string, error) {
+	normalised := strings.TrimSpace(hostname)
+
+	if strings.Contains(normalised, "://") {
+		parsedUrl, err := url.Parse(normalised)
+		if err != nil {
+			return "", fmt.Errorf("the hostname %q is not a valid url: %w", hostname, err)
+		}
+		if parsedUrl.Scheme != "sb" && parsedUrl.Scheme != "https" {
+			return "", fmt.Errorf("the hostname %q must use the sb:// or https:// scheme", hostname)
+		}
+		if strings.Trim(parsedUrl.Path, "/") != "" {
+			return "", fmt.Errorf("the hostname %q must not contain a path, remove %q", hostname, parsedUrl.Path)
+		}
+
+		normalised = parsedUrl.Host
+	}
+
+	normalised = strings.TrimSuffix(normalised, "/")
+
+	if !hostnameRegex.MatchString(normalised) {
+		return "", fmt.Errorf("%q is not a valid hostname or namespace name", hostname)
+	}
+
+	if !strings.ContainsAny(normalised, ".:") && !strings.EqualFold(normalised, "localhost") {
+		if endpointSuffix == "" {
+			endpointSuffix = cloudEnvironments[DEFAULT_CLOUD_ENVIRONMENT].EndpointSuffix
+		}
+		normalised += "." + endpointSuffix
+	}
+
+	return normalised, nil
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHostname(t *testing.T) {
+	cases := map[string]string{
+		"my-namespace":                                     "my-namespace.servicebus.windows.net",
+		"My-Namespace.servicebus.windows.net":              "My-Namespace.servicebus.windows.net",
+		"sb://My-Namespace.servicebus.windows.net/":        "My-Namespace.servicebus.windows.net",
+		" my-namespace.servicebus.windows.net/ ":           "my-namespace.servicebus.windows.net",
+		"sb://my-namespace.servicebus.windows.net/":        "my-namespace.servicebus.windows.net",
+		"https://my-namespace.servicebus.windows.net:443/": "my-namespace.servicebus.windows.net:443",
+		"localhost":      "localhost",
+		"localhost:5300": "localhost:5300",
+	}
+
+	for hostname, expected := range cases {
+		normalised, err := NormalizeHostname(hostname, "")
+
+		require.NoError(t, err, hostname)
+		assert.Equal(t, expected, normalised, hostname)
+	}
+}
+
+func TestNormalizeHostname_EndpointSuffixOfCloud(t *testing.T) {
+	china, _ := GetCloudEnvironment("china", "", "")
+
+	hostname, err := AuthConfig{Cloud: china}.GetHostname("my-namespace")
+
+	require.NoError(t, err)
+	assert.Equal(t, "my-namespace.servicebus.chinacloudapi.cn", hostname)
+}
+
+func TestNormalizeHostname_Errors(t *testing.T) {
+	_, err := NormalizeHostname("amqps://my-namespace.servicebus.windows.net", "")
+	assert.ErrorContains(t, err, "sb:// or https:// scheme")
+
+	_, err = NormalizeHostname("sb://my-namespace.servicebus.windows.net/my-queue", "")
+	assert.ErrorContains(t, err, "must not contain a path")
+
+	_, err = NormalizeHostname("my namespace", "")
+	assert.ErrorContains(t, err, "not a valid hostname")
+}
+
+func TestGetHostname(t *testing.T) {
+	connectionString := "Endpoint=sb://my-namespace.servicebus.windows.net/;SharedAccessKeyName=Root;SharedAccessKey=key"
+
+	hostname, err := AuthConfig{ConnectionString: connectionString}.GetHostname("")
+	require.NoError(t, err)
+	assert.Equal(t, "my-namespace.servicebus.windows.net", hostname)
+
+	hostname, err = AuthConfig{ConnectionString: connectionString}.GetHostname("My-Namespace.servicebus.windows.net")
+	require.NoError(t, err)
+	assert.Equal(t, "my-namespace.servicebus.windows.net", hostname)
+
+	_, err = AuthConfig{ConnectionString: connectionString}.GetHostname("other.servicebus.windows.net")
+	assert.ErrorContains(t, err, "does not match")
+
+	_, err = AuthConfig{ConnectionString: "SharedAccessKeyName=Root;SharedAccessKey=key"}.GetHostname("")
+	assert.ErrorContains(t, err, "valid Endpoint")
+
+	_, err = AuthConfig{}.GetHostname("")
+	assert.ErrorContains(t, err, "'azure_servicebus_hostname' or the DG_SERVICEBUS_HOSTNAME environment variable is required")
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// The audience Entra ID expects in federated tokens.
+const OIDC_AUDIENCE = "api://AzureADTokenExchange"
+
+// requestOidcToken requests an id token from the token endpoint of the CI system, like GitHub Actions does
+// with ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN. The default HTTP client is used, when transport is nil.
+func requestOidcToken(ctx context.Context, transport policy.Transporter, requestUrl string, requestToken string) (string, error) {
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid OIDC request url: %w", err)
+	}
+
+	query := parsedUrl.Query()
+	query.Set("audience", OIDC_AUDIENCE)
+	parsedUrl.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedUrl.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", "Bearer "+requestToken)
+
+	if transport == nil {
+		transport = http.DefaultClient
+	}
+
+	response, err := transport.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("could not request the OIDC token: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("could not read the OIDC token response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting the OIDC token failed with status %d: %s", response.StatusCode, string(body))
+	}
+
+	var tokenResponse struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("could not parse the OIDC token response: %w", err)
+	}
+
+	if tokenResponse.Value == "" {
+		return "", fmt.Errorf("the OIDC token response does not contain a token")
+	}
+
+	return tokenResponse.Value, nil
+}
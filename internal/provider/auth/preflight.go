@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+const SERVICE_BUS_DATA_OWNER_ROLE = "Azure Service Bus Data Owner"
+
+// The Entra ID error codes of a credential, which is used with another tenant than it belongs to
+var wrongTenantErrorCodes = []string{
+	"aadsts90002",  // Tenant not found
+	"aadsts700016", // Application not found in the directory
+	"aadsts50020",  // User account does not exist in the tenant
+	"aadsts900023", // Invalid tenant identifier
+}
+
+// The Entra ID error codes of an expired or revoked login
+var expiredLoginErrorCodes = []string{
+	"aadsts70043",  // Refresh token expired due to conditional access
+	"aadsts700082", // Refresh token expired due to inactivity
+	"aadsts50173",  // Grant expired, because the password changed
+	"aadsts50078",  // Multi-factor authentication expired
+}
+
+// DiagnoseError explains an error of the preflight, or of any other call to the namespace at hostname, with a summary
+// and an actionable detail for the most common misconfigurations.
+func (f *AdminClientFactory) DiagnoseError(err error, hostname string) (string, string) {
+	message := strings.ToLower(err.Error())
+
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return "Azure Service Bus Hostname Not Found", fmt.Sprintf("The hostname %s could not be resolved. "+
+			"Check 'azure_servicebus_hostname' for typos and that the namespace exists in the %s cloud. "+
+			"A namespace behind a private endpoint needs a private DNS zone, which can be resolved where Terraform runs.",
+			hostname, f.config.Cloud.Name)
+	}
+
+	// The credentials name themselves in their errors, like DefaultAzureCredential or AzureCLICredential
+	var responseError *azcore.ResponseError
+	var authenticationError *azidentity.AuthenticationFailedError
+	isResponseError := errors.As(err, &responseError)
+	isCredentialError := errors.As(err, &authenticationError) || (!isResponseError && strings.Contains(message, "credential"))
+
+	switch {
+	case isCredentialError && strings.Contains(message, "az login"), containsAny(message, expiredLoginErrorCodes):
+		return "Azure Login Expired", "The login of the Azure CLI, or of the signed-in account, has expired or was revoked. " +
+			"Run 'az login' again, with '--tenant' set to the tenant of the namespace, or configure a service principal, " +
+			"a managed identity or OIDC for unattended runs."
+	case containsAny(message, wrongTenantErrorCodes):
+		return "Wrong Tenant", fmt.Sprintf("Entra ID rejected the credential for the tenant %q. "+
+			"Check that 'tenant_id' is the tenant of the namespace and that 'client_id' is registered in that tenant.", f.config.TenantId)
+	case isCredentialError:
+		return "Azure Authentication Failed", fmt.Sprintf("No token could be acquired with the %s authentication method. "+
+			"Check the credentials and, when authenticating with the default credential, that one of its token sources is available.", f.Method)
+	}
+
+	if !isResponseError {
+		return "Azure Service Bus Unreachable", fmt.Sprintf("The namespace %s could not be reached. "+
+			"Check the network rules of the namespace, a firewall or proxy between Terraform and Azure, "+
+			"and that 'environment' matches the cloud of the namespace.", hostname)
+	}
+
+	switch responseError.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if strings.Contains(message, "issuer") || strings.Contains(message, "audience") {
+			return "Wrong Tenant", fmt.Sprintf("The namespace %s rejected the token, as it was issued for another tenant or cloud. "+
+				"Check that 'tenant_id' is the tenant of the subscription of the namespace and that 'environment' matches its cloud.", hostname)
+		}
+
+		if f.Method == AUTH_METHOD_CONNECTION_STRING || f.Method == AUTH_METHOD_SHARED_ACCESS_KEY {
+			return "Missing Manage Claim", fmt.Sprintf("The shared access policy cannot manage the namespace %s. "+
+				"Use a policy with the Manage claim, for example RootManageSharedAccessKey, and check that the key is current.", hostname)
+		}
+
+		return "Missing " + SERVICE_BUS_DATA_OWNER_ROLE + " Role", fmt.Sprintf("The identity authenticated with the %s method cannot manage the namespace %s. "+
+			"Assign it the %q role on the namespace, for example with "+
+			"'az role assignment create --role %q --assignee <client id or user> --scope <resource id of the namespace>'. "+
+			"New role assignments can take a few minutes to take effect.",
+			f.Method, hostname, SERVICE_BUS_DATA_OWNER_ROLE, SERVICE_BUS_DATA_OWNER_ROLE)
+	case http.StatusNotFound:
+		return "Azure Service Bus Namespace Not Found", fmt.Sprintf("The namespace %s does not exist or does not serve the management API. "+
+			"Check 'azure_servicebus_hostname'.", hostname)
+	default:
+		return "Azure Service Bus Request Failed", fmt.Sprintf("The namespace %s answered with status %d.", hostname, responseError.StatusCode)
+	}
+}
+
+func containsAny(message string, values []string) bool {
+	for _, value := range values {
+		if strings.Contains(message, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnoseError(t *testing.T) {
+	servicePrincipal := &AdminClientFactory{Method: AUTH_METHOD_CLIENT_SECRET, config: AuthConfig{TenantId: "my-tenant"}}
+	sharedAccessKey := &AdminClientFactory{Method: AUTH_METHOD_SHARED_ACCESS_KEY}
+	unauthorized := func(message string) error {
+		return &azcore.ResponseError{ErrorCode: message, StatusCode: http.StatusUnauthorized, RawResponse: &http.Response{StatusCode: http.StatusUnauthorized}}
+	}
+
+	for _, test := range []struct {
+		name     string
+		factory  *AdminClientFactory
+		err      error
+		expected string
+	}{
+		{"dns", servicePrincipal, fmt.Errorf("fetching failed: %w", &net.DNSError{Err: "no such host", Name: "typo.servicebus.windows.net", IsNotFound: true}), "Azure Service Bus Hostname Not Found"},
+		{"cli login", servicePrincipal, errors.New("AzureCLICredential: ERROR: Please run 'az login' to setup account."), "Azure Login Expired"},
+		{"refresh token expired", servicePrincipal, errors.New("DefaultAzureCredential: AADSTS700082: The refresh token has expired due to inactivity."), "Azure Login Expired"},
+		{"tenant not found", servicePrincipal, errors.New("ClientSecretCredential: AADSTS90002: Tenant 'my-tenant' not found."), "Wrong Tenant"},
+		{"other credential error", servicePrincipal, errors.New("ClientSecretCredential: AADSTS7000215: Invalid client secret provided."), "Azure Authentication Failed"},
+		{"token of another tenant", servicePrincipal, unauthorized("InvalidIssuer: Token issuer is invalid."), "Wrong Tenant"},
+		{"missing role", servicePrincipal, unauthorized("Unauthorized access for 'ListQueues' operation"), "Missing Azure Service Bus Data Owner Role"},
+		{"missing manage claim", sharedAccessKey, unauthorized("Unauthorized access. 'Manage' claim(s) are required for this operation."), "Missing Manage Claim"},
+		{"connection refused", servicePrincipal, errors.New("dial tcp 10.0.0.1:443: connect: connection refused"), "Azure Service Bus Unreachable"},
+	} {
+		summary, detail := test.factory.DiagnoseError(test.err, "my-namespace.servicebus.windows.net")
+
+		assert.Equal(t, test.expected, summary, test.name)
+		assert.NotEmpty(t, detail, test.name)
+	}
+}
+
+func TestDiagnoseError_MissingRoleExplainsAssignment(t *testing.T) {
+	factory := &AdminClientFactory{Method: AUTH_METHOD_MANAGED_IDENTITY}
+	err := &azcore.ResponseError{StatusCode: http.StatusForbidden, RawResponse: &http.Response{StatusCode: http.StatusForbidden}}
+
+	_, detail := factory.DiagnoseError(err, "my-namespace.servicebus.windows.net")
+
+	assert.Contains(t, detail, "az role assignment create --role \"Azure Service Bus Data Owner\"")
+	assert.Contains(t, detail, "managed_identity")
+}
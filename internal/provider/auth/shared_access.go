@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+func (c AuthConfig) getSharedAccessAuthMethod() (AuthMethod, error) {
+	if c.UseOidc || c.UseMsi {
+		return "", fmt.Errorf("a connection string or shared access key cannot be combined with 'use_oidc' or 'use_msi', choose one way to authenticate")
+	}
+
+	if c.ConnectionString != "" {
+		if c.SharedAccessKeyName != "" || c.SharedAccessKey != "" {
+			return "", fmt.Errorf("'connection_string' cannot be combined with 'shared_access_key_name' and 'shared_access_key', " +
+				"the connection string already contains the key")
+		}
+
+		return AUTH_METHOD_CONNECTION_STRING, nil
+	}
+
+	if c.SharedAccessKeyName == "" || c.SharedAccessKey == "" {
+		return "", fmt.Errorf("'shared_access_key_name' and 'shared_access_key' must be set together")
+	}
+
+	return AUTH_METHOD_SHARED_ACCESS_KEY, nil
+}
+
+func isDevelopmentEmulatorConnectionString(connectionString string) bool {
+	useEmulator, _ := strconv.ParseBool(getConnectionStringValue(connectionString, "UseDevelopmentEmulator"))
+	return useEmulator
+}
+
+func getConnectionStringValue(connectionString string, key string) string {
+	for _, part := range strings.Split(connectionString, ";") {
+		keyAndValue := strings.SplitN(part, "=", 2)
+		if len(keyAndValue) == 2 && strings.EqualFold(strings.TrimSpace(keyAndValue[0]), key) {
+			return strings.TrimSpace(keyAndValue[1])
+		}
+	}
+
+	return ""
+}
+
+// plainHttpPolicy sends requests over HTTP, as the management endpoint of the Service Bus emulator does not support TLS.
+// The admin client always builds https urls.
+type plainHttpPolicy struct{}
+
+func (plainHttpPolicy) Do(request *policy.Request) (*http.Response, error) {
+	request.Raw().URL.Scheme = "http"
+	return request.Next()
+}
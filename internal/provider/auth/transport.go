@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// The prefix of the application id in the user agent, which is limited to 24 characters by the SDK.
+const USER_AGENT_APPLICATION = "dgservicebus"
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TransportOptions controls the HTTP client of the admin clients and of the token requests.
+type TransportOptions struct {
+	ProxyUrl           string        // The proxy of all requests, HTTPS_PROXY and NO_PROXY are used when not set
+	CaBundlePath       string        // A PEM file with certificates, which are trusted in addition to the system ones
+	MinTlsVersion      string        // 1.2 or 1.3, 1.2 when not set
+	InsecureSkipVerify bool          // Disables the verification of server certificates, only meant for debugging
+	RequestTimeout     time.Duration // The timeout of every single request, no timeout when not set
+	UserAgentSuffix    string        // Appended to the user agent of every request
+	ApiVersion         string        // Overrides the version of the management API, which the admin client requests
+	ProviderVersion    string        // Added to the user agent
+}
+
+func GetTlsVersionNames() []string {
+	return []string{"1.2", "1.3"}
+}
+
+// NewClientOptions creates the options of the Azure SDK clients, which send all requests with the transport and identify
+// the provider in the user agent.
+func (o TransportOptions) NewClientOptions() (azcore.ClientOptions, error) {
+	transport, err := o.newTransport()
+	if err != nil {
+		return azcore.ClientOptions{}, err
+	}
+
+	options := azcore.ClientOptions{
+		Transport: transport,
+		Telemetry: policy.TelemetryOptions{
+			ApplicationID: USER_AGENT_APPLICATION + "/" + o.ProviderVersion,
+		},
+	}
+
+	if o.UserAgentSuffix != "" {
+		options.PerCallPolicies = append(options.PerCallPolicies, userAgentSuffixPolicy{suffix: o.UserAgentSuffix})
+	}
+
+	// The admin client does not support ClientOptions.APIVersion, it always requests its own version
+	if o.ApiVersion != "" {
+		options.PerCallPolicies = append(options.PerCallPolicies, apiVersionPolicy{version: o.ApiVersion})
+	}
+
+	return options, nil
+}
+
+func (o TransportOptions) newTransport() (*http.Client, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	if o.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(o.ProxyUrl)
+		if err != nil || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("the proxy url %q is not a valid url", o.ProxyUrl)
+		}
+		if proxyUrl.Scheme != "http" && proxyUrl.Scheme != "https" && proxyUrl.Scheme != "socks5" {
+			return nil, fmt.Errorf("the proxy url %q must use http, https or socks5", o.ProxyUrl)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.MinTlsVersion != "" {
+		version, ok := tlsVersions[o.MinTlsVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS version %q, expected one of %s", o.MinTlsVersion, strings.Join(GetTlsVersionNames(), ", "))
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if o.CaBundlePath != "" {
+		rootCAs, err := loadCaBundle(o.CaBundlePath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport, Timeout: o.RequestTimeout}, nil
+}
+
+// loadCaBundle returns the system certificates together with the ones in the PEM file at path.
+func loadCaBundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CA bundle: %w", err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if !rootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("the CA bundle %s does not contain any PEM encoded certificate", path)
+	}
+
+	return rootCAs, nil
+}
+
+type userAgentSuffixPolicy struct {
+	suffix string
+}
+
+func (p userAgentSuffixPolicy) Do(request *policy.Request) (*http.Response, error) {
+	header := request.Raw().Header
+	header.Set("User-Agent", strings.TrimSpace(header.Get("User-Agent")+" "+p.suffix))
+	return request.Next()
+}
+
+type apiVersionPolicy struct {
+	version string
+}
+
+func (p apiVersionPolicy) Do(request *policy.Request) (*http.Response, error) {
+	query := request.Raw().URL.Query()
+	query.Set("api-version", p.version)
+	request.Raw().URL.RawQuery = query.Encode()
+	return request.Next()
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportOptions_UserAgentApiVersionAndCaBundle(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caBundlePath, caBundle, 0600))
+
+	clientOptions, err := TransportOptions{
+		CaBundlePath:    caBundlePath,
+		MinTlsVersion:   "1.2",
+		UserAgentSuffix: "my-pipeline",
+		ApiVersion:      "2017-04",
+		ProviderVersion: "1.2.3",
+	}.NewClientOptions()
+	require.NoError(t, err)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	config := AuthConfig{ConnectionString: "Endpoint=sb://" + host + "/;SharedAccessKeyName=Root;SharedAccessKey=key"}
+	client, _, err := NewAdminClient(config, host, az.ClientOptions{ClientOptions: clientOptions})
+	require.NoError(t, err)
+
+	_, err = client.GetQueue(context.Background(), "my-queue", nil)
+	require.NoError(t, err)
+
+	request := <-requests
+	assert.True(t, strings.HasPrefix(request.UserAgent(), "dgservicebus/1.2.3 azsdk-go-azsbadmin/"), request.UserAgent())
+	assert.True(t, strings.HasSuffix(request.UserAgent(), " my-pipeline"), request.UserAgent())
+	assert.Equal(t, "2017-04", request.URL.Query().Get("api-version"))
+}
+
+func TestTransportOptions_Errors(t *testing.T) {
+	emptyBundlePath := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(emptyBundlePath, []byte("not a certificate"), 0600))
+
+	for _, test := range []struct {
+		name     string
+		options  TransportOptions
+		expected string
+	}{
+		{"proxy without host", TransportOptions{ProxyUrl: "proxy:3128"}, "not a valid url"},
+		{"proxy scheme", TransportOptions{ProxyUrl: "ftp://proxy:3128"}, "must use http, https or socks5"},
+		{"tls version", TransportOptions{MinTlsVersion: "1.1"}, "unknown TLS version"},
+		{"missing ca bundle", TransportOptions{CaBundlePath: filepath.Join(t.TempDir(), "missing.pem")}, "could not read the CA bundle"},
+		{"empty ca bundle", TransportOptions{CaBundlePath: emptyBundlePath}, "does not contain any PEM encoded certificate"},
+	} {
+		_, err := test.options.NewClientOptions()
+
+		assert.ErrorContains(t, err, test.expected, test.name)
+	}
+}
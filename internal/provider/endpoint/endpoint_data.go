@@ -3,14 +3,13 @@ package endpoint
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"terraform-provider-dg-servicebus/internal/provider/asb"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 
-	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -33,25 +32,50 @@ func (d *endpointDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*az.Client)
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data source Configuration Type",
-			fmt.Sprintf("Expected *azservicebus.Client, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = &asb.AsbClientWrapper{
-		Client: client,
-	}
+	d.client = client
 }
 
 type endpointDataSourceModel struct {
+	Namespace     types.String                          `tfsdk:"namespace"`
 	EndpointName  types.String                          `tfsdk:"endpoint_name"`
 	TopicName     types.String                          `tfsdk:"topic_name"`
 	Subscriptions []endpointDataSourceSubscriptionModel `tfsdk:"subscriptions"`
 	QueueOptions  *endpointDataSourceQueueOptionsModel  `tfsdk:"queue_options"`
+	ForwardTo     types.String                          `tfsdk:"forward_to"`
+	Status        types.String                          `tfsdk:"status"`
+	Rules         []endpointDataSourceRuleModel         `tfsdk:"rules"`
+}
+
+type endpointDataSourceRuleModel struct {
+	Name              types.String                              `tfsdk:"name"`
+	FilterType        types.String                              `tfsdk:"filter_type"`
+	SqlExpression     types.String                              `tfsdk:"sql_expression"`
+	SqlParameters     map[string]string                         `tfsdk:"sql_parameters"`
+	CorrelationFilter *endpointDataSourceCorrelationFilterModel `tfsdk:"correlation_filter"`
+	Action            types.String                              `tfsdk:"action"`
+	ManagedFormat     types.Bool                                `tfsdk:"managed_format"`
+	ManagedFilter     types.String                              `tfsdk:"managed_filter"`
+}
+
+type endpointDataSourceCorrelationFilterModel struct {
+	MessageID             types.String      `tfsdk:"message_id"`
+	CorrelationID         types.String      `tfsdk:"correlation_id"`
+	To                    types.String      `tfsdk:"to"`
+	ReplyTo               types.String      `tfsdk:"reply_to"`
+	Subject               types.String      `tfsdk:"subject"`
+	SessionID             types.String      `tfsdk:"session_id"`
+	ReplyToSessionID      types.String      `tfsdk:"reply_to_session_id"`
+	ContentType           types.String      `tfsdk:"content_type"`
+	ApplicationProperties map[string]string `tfsdk:"application_properties"`
 }
 
 type endpointDataSourceSubscriptionModel struct {
@@ -81,6 +105,10 @@ func (d *endpointDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 		Description: "The Endpoint data source porvides information about an existing Endpoint.",
 
 		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
 			"endpoint_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the endpoint.",
@@ -119,6 +147,90 @@ func (d *endpointDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					},
 				},
 			},
+			"forward_to": schema.StringAttribute{
+				Computed:    true,
+				Description: "The entity the endpoint subscription forwards messages to.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the endpoint subscription.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Every rule of the endpoint subscription, including the ones not created by this provider.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ruleDetailsSchemaAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// ruleDetailsSchemaAttributes returns the computed attributes describing any rule, also the ones not created by this provider.
+func ruleDetailsSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the rule.",
+		},
+		"filter_type": schema.StringAttribute{
+			Computed:    true,
+			Description: "The filter type of the rule. Either 'sql', 'correlation', 'true', 'false' or 'unknown'.",
+		},
+		"sql_expression": schema.StringAttribute{
+			Computed:    true,
+			Description: "The expression of the sql filter.",
+		},
+		"sql_parameters": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "The parameters of the sql filter.",
+		},
+		"correlation_filter": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The properties of the correlation filter.",
+			Attributes: map[string]schema.Attribute{
+				"message_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"correlation_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"to": schema.StringAttribute{
+					Computed: true,
+				},
+				"reply_to": schema.StringAttribute{
+					Computed: true,
+				},
+				"subject": schema.StringAttribute{
+					Computed: true,
+				},
+				"session_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"reply_to_session_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"content_type": schema.StringAttribute{
+					Computed: true,
+				},
+				"application_properties": schema.MapAttribute{
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+			},
+		},
+		"action": schema.StringAttribute{
+			Computed:    true,
+			Description: "The expression of the sql action of the rule.",
+		},
+		"managed_format": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the rule has the format of the rules created by this provider.",
+		},
+		"managed_filter": schema.StringAttribute{
+			Computed:    true,
+			Description: "The filter of the subscription, when the rule has the format of the rules created by this provider.",
 		},
 	}
 }
@@ -145,11 +257,25 @@ func (model endpointDataSourceModel) ToAsbModel() asb.AsbEndpointModel {
 func (d *endpointDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state endpointDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
 	state.QueueOptions = &endpointDataSourceQueueOptionsModel{}
 
 	model := state.ToAsbModel()
 
-	asbSubscriptions, err := d.client.GetAsbSubscriptionsRules(ctx, model)
+	asbSubscriptions, err := client.GetAsbSubscriptionsRules(ctx, model)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -169,7 +295,58 @@ func (d *endpointDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	state.Subscriptions = subscriptions
 
-	queue, err := d.client.GetEndpointQueue(ctx, model)
+	subscription, err := client.GetEndpointSubscription(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Subscription",
+			"Could not get Subscription, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if subscription == nil {
+		resp.Diagnostics.AddError(
+			"Subscription does not exist",
+			fmt.Sprintf("No Subscription for Endpoint %s exist on topic %s", model.EndpointName, model.TopicName),
+		)
+		return
+	}
+
+	state.ForwardTo = types.StringPointerValue(subscription.ForwardTo)
+	state.Status = types.StringNull()
+	if subscription.Status != nil {
+		state.Status = types.StringValue(string(*subscription.Status))
+	}
+
+	rules, err := client.GetEndpointSubscriptionRuleDetails(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Rules",
+			"Could not get Rules, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Rules = make([]endpointDataSourceRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		state.Rules = append(state.Rules, convertAsbRuleDetailsToRuleModel(rule))
+	}
+
+	// Rules, which cannot be converted to a filter, are only listed in the rules
+	subscriptionRuleNames := map[string]bool{"$Default": true}
+	for _, asbSubscription := range asbSubscriptions {
+		subscriptionRuleNames[asbSubscription.Name] = true
+	}
+	for _, rule := range rules {
+		if !subscriptionRuleNames[rule.Name] {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Rule %s of endpoint %s is not a subscription", rule.Name, model.EndpointName),
+				fmt.Sprintf("The %s rule could not be converted to a filter, thus it is missing in subscriptions. See rules for its details.", rule.FilterType),
+			)
+		}
+	}
+
+	queue, err := client.GetEndpointQueue(ctx, model)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error getting Queue",
@@ -202,6 +379,38 @@ func convertAsbFilterToEnpointFilter(asbSubscription asb.AsbSubscriptionRule) ba
 		return basetypes.NewStringValue(asbSubscription.Filter)
 	}
 
-	foundFilter := regexp.MustCompile(`^\[NServiceBus\.EnclosedMessageTypes\] LIKE '%([a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*)%'$`).FindStringSubmatch(asbSubscription.Filter)
-	return basetypes.NewStringValue(foundFilter[1])
+	filter, ok := asb.DecodeManagedSqlFilterExpression(asbSubscription.Filter)
+	if !ok {
+		// Not created by this provider, so we return the expression as is
+		return basetypes.NewStringValue(asbSubscription.Filter)
+	}
+	return basetypes.NewStringValue(filter)
+}
+
+func convertAsbRuleDetailsToRuleModel(rule asb.AsbSubscriptionRuleDetails) endpointDataSourceRuleModel {
+	model := endpointDataSourceRuleModel{
+		Name:          types.StringValue(rule.Name),
+		FilterType:    types.StringValue(rule.FilterType),
+		SqlExpression: types.StringPointerValue(rule.SqlExpression),
+		SqlParameters: rule.SqlParameters,
+		Action:        types.StringPointerValue(rule.Action),
+		ManagedFormat: types.BoolValue(rule.ManagedFormat),
+		ManagedFilter: types.StringPointerValue(rule.ManagedFilter),
+	}
+
+	if rule.CorrelationFilter != nil {
+		model.CorrelationFilter = &endpointDataSourceCorrelationFilterModel{
+			MessageID:             types.StringPointerValue(rule.CorrelationFilter.MessageID),
+			CorrelationID:         types.StringPointerValue(rule.CorrelationFilter.CorrelationID),
+			To:                    types.StringPointerValue(rule.CorrelationFilter.To),
+			ReplyTo:               types.StringPointerValue(rule.CorrelationFilter.ReplyTo),
+			Subject:               types.StringPointerValue(rule.CorrelationFilter.Subject),
+			SessionID:             types.StringPointerValue(rule.CorrelationFilter.SessionID),
+			ReplyToSessionID:      types.StringPointerValue(rule.CorrelationFilter.ReplyToSessionID),
+			ContentType:           types.StringPointerValue(rule.CorrelationFilter.ContentType),
+			ApplicationProperties: rule.CorrelationFilter.ApplicationProperties,
+		}
+	}
+
+	return model
 }
@@ -0,0 +1,228 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &endpointHealthDataSource{}
+	_ datasource.DataSourceWithConfigure = &endpointHealthDataSource{}
+)
+
+func NewEndpointHealthDataSource() datasource.DataSource {
+	return &endpointHealthDataSource{}
+}
+
+type endpointHealthDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *endpointHealthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type endpointHealthDataSourceModel struct {
+	Namespace    types.String                     `tfsdk:"namespace"`
+	EndpointName types.String                     `tfsdk:"endpoint_name"`
+	TopicName    types.String                     `tfsdk:"topic_name"`
+	Queue        *endpointHealthQueueModel        `tfsdk:"queue"`
+	Subscription *endpointHealthSubscriptionModel `tfsdk:"subscription"`
+}
+
+type endpointHealthQueueModel struct {
+	ActiveMessageCount             types.Int64  `tfsdk:"active_message_count"`
+	DeadLetterMessageCount         types.Int64  `tfsdk:"dead_letter_message_count"`
+	ScheduledMessageCount          types.Int64  `tfsdk:"scheduled_message_count"`
+	TransferMessageCount           types.Int64  `tfsdk:"transfer_message_count"`
+	TransferDeadLetterMessageCount types.Int64  `tfsdk:"transfer_dead_letter_message_count"`
+	TotalMessageCount              types.Int64  `tfsdk:"total_message_count"`
+	SizeInBytes                    types.Int64  `tfsdk:"size_in_bytes"`
+	AccessedAt                     types.String `tfsdk:"accessed_at"`
+}
+
+type endpointHealthSubscriptionModel struct {
+	ActiveMessageCount             types.Int64  `tfsdk:"active_message_count"`
+	DeadLetterMessageCount         types.Int64  `tfsdk:"dead_letter_message_count"`
+	TransferMessageCount           types.Int64  `tfsdk:"transfer_message_count"`
+	TransferDeadLetterMessageCount types.Int64  `tfsdk:"transfer_dead_letter_message_count"`
+	TotalMessageCount              types.Int64  `tfsdk:"total_message_count"`
+	AccessedAt                     types.String `tfsdk:"accessed_at"`
+}
+
+func (d *endpointHealthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint_health"
+}
+
+func (d *endpointHealthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	messageCountAttributes := func(includeQueueOnly bool) map[string]schema.Attribute {
+		attributes := map[string]schema.Attribute{
+			"active_message_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of active messages.",
+			},
+			"dead_letter_message_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of messages in the dead-letter queue.",
+			},
+			"transfer_message_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of messages, which are yet to be forwarded.",
+			},
+			"transfer_dead_letter_message_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of messages, which could not be forwarded and are in the transfer dead-letter queue.",
+			},
+			"total_message_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The total number of messages.",
+			},
+			"accessed_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the entity was last accessed, in RFC 3339 format.",
+			},
+		}
+
+		if includeQueueOnly {
+			attributes["scheduled_message_count"] = schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of messages, which are scheduled to be enqueued.",
+			}
+			attributes["size_in_bytes"] = schema.Int64Attribute{
+				Computed:    true,
+				Description: "The size of the queue, in bytes.",
+			}
+		}
+
+		return attributes
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "The Endpoint Health data source provides the runtime properties of the queue and the subscription of an existing Endpoint, " +
+			"such as its message counts. Useful in check blocks and post-deploy gates.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"endpoint_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the endpoint.",
+			},
+			"topic_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the topic, in which the endpoint is created",
+			},
+			"queue": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The runtime properties of the endpoint queue.",
+				Attributes:  messageCountAttributes(true),
+			},
+			"subscription": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The runtime properties of the endpoint subscription.",
+				Attributes:  messageCountAttributes(false),
+			},
+		},
+	}
+}
+
+func (d *endpointHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state endpointHealthDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	model := asb.AsbEndpointModel{
+		EndpointName: state.EndpointName.ValueString(),
+		TopicName:    state.TopicName.ValueString(),
+	}
+
+	queue, err := client.GetEndpointQueueRuntimeProperties(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Queue runtime properties",
+			"Could not get Queue runtime properties, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if queue == nil {
+		resp.Diagnostics.AddError(
+			"Queue does not exist",
+			fmt.Sprintf("No Queue for Endpoint %s exist", model.EndpointName),
+		)
+		return
+	}
+
+	subscription, err := client.GetEndpointSubscriptionRuntimeProperties(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Subscription runtime properties",
+			"Could not get Subscription runtime properties, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if subscription == nil {
+		resp.Diagnostics.AddError(
+			"Subscription does not exist",
+			fmt.Sprintf("No Subscription for Endpoint %s exist on topic %s", model.EndpointName, model.TopicName),
+		)
+		return
+	}
+
+	state.Queue = &endpointHealthQueueModel{
+		ActiveMessageCount:             types.Int64Value(int64(queue.ActiveMessageCount)),
+		DeadLetterMessageCount:         types.Int64Value(int64(queue.DeadLetterMessageCount)),
+		ScheduledMessageCount:          types.Int64Value(int64(queue.ScheduledMessageCount)),
+		TransferMessageCount:           types.Int64Value(int64(queue.TransferMessageCount)),
+		TransferDeadLetterMessageCount: types.Int64Value(int64(queue.TransferDeadLetterMessageCount)),
+		TotalMessageCount:              types.Int64Value(queue.TotalMessageCount),
+		SizeInBytes:                    types.Int64Value(queue.SizeInBytes),
+		AccessedAt:                     types.StringValue(queue.AccessedAt.Format(time.RFC3339)),
+	}
+
+	state.Subscription = &endpointHealthSubscriptionModel{
+		ActiveMessageCount:             types.Int64Value(int64(subscription.ActiveMessageCount)),
+		DeadLetterMessageCount:         types.Int64Value(int64(subscription.DeadLetterMessageCount)),
+		TransferMessageCount:           types.Int64Value(int64(subscription.TransferMessageCount)),
+		TransferDeadLetterMessageCount: types.Int64Value(int64(subscription.TransferDeadLetterMessageCount)),
+		TotalMessageCount:              types.Int64Value(subscription.TotalMessageCount),
+		AccessedAt:                     types.StringValue(subscription.AccessedAt.Format(time.RFC3339)),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"terraform-provider-dg-servicebus/internal/provider/asb"
 
-	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
@@ -14,6 +16,7 @@ var (
 	_ resource.ResourceWithConfigure    = &endpointResource{}
 	_ resource.ResourceWithImportState  = &endpointResource{}
 	_ resource.ResourceWithUpgradeState = &endpointResource{}
+	_ resource.ResourceWithModifyPlan   = &endpointResource{}
 )
 
 func NewEndpointResource() resource.Resource {
@@ -29,18 +32,31 @@ func (r *endpointResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*az.Client)
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configuration Type",
-			fmt.Sprintf("Expected *azservicebus.Client, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = &asb.AsbClientWrapper{
-		Client: client,
+	r.client = client
+}
+
+// forNamespace returns the resource bound to the client of the namespace attribute, the client of the provider when not set.
+func (r *endpointResource) forNamespace(namespace types.String, diagnostics *diag.Diagnostics) (*endpointResource, bool) {
+	client, err := r.client.ForNamespace(namespace.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return nil, false
 	}
+
+	return &endpointResource{client: client}, true
 }
 
 func (r *endpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,5 +64,5 @@ func (r *endpointResource) Metadata(ctx context.Context, req resource.MetadataRe
 }
 
 func (r *endpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = NewSchemaV1()
+	resp.Schema = NewSchemaV2(ctx)
 }
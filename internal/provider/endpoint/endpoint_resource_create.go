@@ -9,7 +9,6 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func (r *endpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -19,8 +18,23 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	r, ok := r.forNamespace(plan.Namespace, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
 	model := plan.ToAsbModel()
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_CREATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	defer addTimeoutError(ctx, &resp.Diagnostics, "create", model, createTimeout)
+
 	var err error
 
 	// Abort if subscription exists
@@ -37,6 +51,16 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// Every step, which succeeded, is recorded, such that a failure leaves an accurate state or is rolled back
+	existing := plan
+	existingPrivateState := endpointPrivateState{}
+	applyMissingQueueToState(&existing, &existingPrivateState)
+	applyMissingEndpointToState(&existing, &existingPrivateState)
+	if existing.AdditionalQueues != nil {
+		existing.AdditionalQueues = []string{}
+	}
+	transaction := newEndpointTransaction(existing, existingPrivateState)
+
 	// Only create queue if not existing
 	queueExists, err := r.client.QueueExists(ctx, model.EndpointName)
 	if err != nil {
@@ -47,18 +71,24 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 	}
 	if !queueExists {
 		if !r.createEndpointQueue(ctx, model, resp) {
+			r.failCreate(ctx, transaction, plan, resp)
 			return
 		}
+		transaction.complete(queueStep(model, plan.QueueOptions, func(ctx context.Context) error {
+			return r.client.DeleteEndpointQueue(ctx, model)
+		}))
 	} else {
 		resp.Diagnostics.AddWarning(
 			fmt.Sprintf("Queue %v for endpoint %v already exists.", model.EndpointName, model.EndpointName),
 			"This suggests that the queue may have been created manually or that the endpoint already exists, possibly deployed in another infrastructure deployment."+
 				"If you did not intend to import this endpoint, you can remove it from the Terraform state using `terraform state rm` command, or you can contact the platform for support.",
 		)
+		transaction.complete(queueStep(model, plan.QueueOptions, nil))
 	}
 
 	// Create additional queues without takeover
-	if !r.createAdditionalQueues(ctx, model, resp) {
+	if !r.createAdditionalQueues(ctx, model, transaction, resp) {
+		r.failCreate(ctx, transaction, plan, resp)
 		return
 	}
 
@@ -69,32 +99,57 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 			"Error creating subscription",
 			"Could not create subscription, unexpected error: "+err.Error(),
 		)
+		r.failCreate(ctx, transaction, plan, resp)
 		return
 	}
-
-	for i := 0; i < len(plan.Subscriptions); i++ {
-		err := r.client.CreateAsbSubscriptionRule(ctx, model, plan.Subscriptions[i].ToAsbModel())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating rule",
-				"Could not create rule, unexpected error: "+err.Error(),
-			)
-			return
-		}
+	transaction.complete(endpointStep(model, func(ctx context.Context) error {
+		return r.client.DeleteEndpoint(ctx, model)
+	}))
+
+	rulePlan := asb.PlanAsbSubscriptionRules(model.Subscriptions, []asb.AsbSubscriptionRule{})
+	completed, errs := r.client.ApplyAsbRulePlan(ctx, model, rulePlan)
+	for _, operation := range completed {
+		operation := operation
+		transaction.complete(ruleStep(operation, nil, func(ctx context.Context) error {
+			return r.client.RevertAsbRuleOperation(ctx, model, operation, nil)
+		}))
+	}
+	for _, err := range errs {
+		resp.Diagnostics.AddError(
+			"Error creating rule",
+			"Could not create rule, unexpected error: "+err.Error(),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		r.failCreate(ctx, transaction, plan, resp)
+		return
 	}
 
 	// Update state
-	plan.QueueExists = types.BoolValue(true)
-	plan.EndpointExists = types.BoolValue(true)
-	plan.ShouldCreateQueue = types.BoolValue(false)
-	plan.ShouldCreateEndpoint = types.BoolValue(false)
-	plan.HasMalformedFilters = types.BoolValue(false)
-	plan.ShouldUpdateSubscriptions = types.BoolValue(false)
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	_, privateState := transaction.result()
+	resp.Diagnostics.Append(setPrivateState(ctx, resp.Private, privateState)...)
+}
+
+// failCreate records the completed steps in the state, which taints the endpoint, such that the next apply
+// replaces it instead of failing on the existing subscription. With rollback_on_failure the steps are undone.
+func (r *endpointResource) failCreate(
+	ctx context.Context,
+	transaction *endpointTransaction,
+	plan endpointResourceModel,
+	resp *resource.CreateResponse,
+) {
+	if !transaction.fail(ctx, plan.RollbackOnFailure, &resp.Diagnostics) {
+		return
+	}
+
+	state, privateState := transaction.result()
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(setPrivateState(ctx, resp.Private, privateState)...)
 }
 
 func (r *endpointResource) createEndpointQueue(ctx context.Context, model asb.AsbEndpointModel, resp *resource.CreateResponse) bool {
@@ -132,8 +187,14 @@ func (r *endpointResource) createEndpointQueue(ctx context.Context, model asb.As
 	return false
 }
 
-func (r *endpointResource) createAdditionalQueues(ctx context.Context, model asb.AsbEndpointModel, resp *resource.CreateResponse) bool {
+func (r *endpointResource) createAdditionalQueues(
+	ctx context.Context,
+	model asb.AsbEndpointModel,
+	transaction *endpointTransaction,
+	resp *resource.CreateResponse,
+) bool {
 	for _, queue := range model.AdditionalQueues {
+		queue := queue
 		queueExists, err := r.client.QueueExists(ctx, queue)
 		if err != nil {
 			resp.Diagnostics.AddWarning(
@@ -148,6 +209,7 @@ func (r *endpointResource) createAdditionalQueues(ctx context.Context, model asb
 				"This suggests that the queue may have been created manually or that the endpoint already exists, possibly deployed in another infrastructure deployment."+
 					"If you did not intend to import this endpoint, you can remove it from the Terraform state using `terraform state rm` command, or you can contact the platform for support.",
 			)
+			transaction.complete(additionalQueueStep(queue, nil))
 			continue
 		}
 
@@ -160,6 +222,9 @@ func (r *endpointResource) createAdditionalQueues(ctx context.Context, model asb
 			return false
 		}
 
+		transaction.complete(additionalQueueStep(queue, func(ctx context.Context) error {
+			return r.client.DeleteAdditionalQueue(ctx, queue)
+		}))
 	}
 	return true
 }
@@ -16,8 +16,23 @@ func (r *endpointResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	r, ok := r.forNamespace(plan.Namespace, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
 	model := plan.ToAsbModel()
 
+	deleteTimeout, diags := plan.Timeouts.Delete(ctx, DEFAULT_DELETE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	defer addTimeoutError(ctx, &resp.Diagnostics, "delete", model, deleteTimeout)
+
 	err := r.client.DeleteEndpointQueue(ctx, model)
 	if err != nil && !statusCodeIsOk(err) {
 		resp.Diagnostics.AddError(
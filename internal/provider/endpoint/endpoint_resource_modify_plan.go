@@ -0,0 +1,113 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ModifyPlan explains the updates and deletes of subscription rules, as the plan shows them as a diff of the whole set.
+// New and replaced endpoints only create rules, which the diff shows plainly. The rules are planned against the rules,
+// which Read found in Service Bus, as Apply plans against them too.
+func (r *endpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return // The endpoint is destroyed or created
+	}
+	if requiresReplace(ctx, req.Plan, req.State, resp) {
+		return
+	}
+
+	var planSubscriptions, stateSubscriptions types.Set
+	var endpointName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("subscriptions"), &planSubscriptions)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("endpoint_name"), &endpointName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("subscriptions"), &stateSubscriptions)...)
+	privateState, diags := getPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, known := getKnownSubscriptions(ctx, planSubscriptions, resp)
+	if !known {
+		return
+	}
+	existingSubscriptions, known := getKnownSubscriptions(ctx, stateSubscriptions, resp)
+	if !known {
+		return
+	}
+
+	// Rules, which were not read yet, are assumed to match the state
+	existing, read := privateState.existingRules()
+	if !read {
+		for _, subscription := range existingSubscriptions {
+			existing = append(existing, asb.GetExpectedAsbSubscriptionRule(subscription.ToAsbModel()))
+		}
+	}
+
+	desiredModels := make([]asb.AsbSubscriptionModel, len(desired))
+	for i, subscription := range desired {
+		desiredModels[i] = subscription.ToAsbModel()
+	}
+
+	rulePlan := asb.PlanAsbSubscriptionRules(desiredModels, existing)
+	if len(rulePlan.Updates) == 0 && len(rulePlan.Deletes) == 0 {
+		return
+	}
+
+	description := asb.DescribeAsbRulePlan(rulePlan, existing)
+
+	detail := "Rules:\n"
+	for _, operation := range description.Operations {
+		detail += "  " + operation + "\n"
+	}
+	detail += "\nRouting impact:\n  " + strings.Join(description.Impacts, "\n  ")
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("subscriptions"),
+		fmt.Sprintf("Subscription rule changes of endpoint %s", endpointName.ValueString()),
+		detail,
+	)
+}
+
+// getKnownSubscriptions returns false, when the subscriptions are not known yet, as they depend on other resources.
+func getKnownSubscriptions(ctx context.Context, subscriptions types.Set, resp *resource.ModifyPlanResponse) ([]SubscriptionModel, bool) {
+	if subscriptions.IsUnknown() {
+		return nil, false
+	}
+
+	models := []SubscriptionModel{}
+	resp.Diagnostics.Append(subscriptions.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return nil, false
+	}
+
+	for _, subscription := range models {
+		if subscription.Filter.IsUnknown() || subscription.FilterType.IsUnknown() {
+			return nil, false
+		}
+	}
+
+	return models, true
+}
+
+// requiresReplace returns true, when an attribute changes, which replaces the endpoint.
+func requiresReplace(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, resp *resource.ModifyPlanResponse) bool {
+	for _, attribute := range []string{"endpoint_name", "topic_name", "namespace"} {
+		var planValue, stateValue types.String
+		resp.Diagnostics.Append(plan.GetAttribute(ctx, path.Root(attribute), &planValue)...)
+		resp.Diagnostics.Append(state.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+
+		if !planValue.Equal(stateValue) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,96 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const PRIVATE_STATE_KEY = "endpoint"
+
+// endpointPrivateState tracks what exists in Service Bus, which is not part of the configuration.
+// It replaces the internal attributes of schema version 1.
+type endpointPrivateState struct {
+	QueueExists    bool                  `json:"queue_exists"`
+	EndpointExists bool                  `json:"endpoint_exists"`
+	Rules          []endpointPrivateRule `json:"rules"` // Nil, when the rules were not read yet
+}
+
+// endpointPrivateRule is a rule, as it exists in Service Bus. Its name can differ from the name derived from the
+// filter in the state, like for rules, which were added manually.
+type endpointPrivateRule struct {
+	Name       string `json:"name"`
+	Filter     string `json:"filter"`
+	FilterType string `json:"filter_type"`
+}
+
+// The private state of the requests and responses, which is of an internal type of the framework.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getPrivateState returns the tracked state. Resources without private state, which were upgraded from
+// schema version 1 or created before, are assumed to exist until they are read.
+func getPrivateState(ctx context.Context, private privateStateGetter) (endpointPrivateState, diag.Diagnostics) {
+	privateState := endpointPrivateState{QueueExists: true, EndpointExists: true}
+
+	value, diags := private.GetKey(ctx, PRIVATE_STATE_KEY)
+	if diags.HasError() || len(value) == 0 {
+		return privateState, diags
+	}
+
+	if err := json.Unmarshal(value, &privateState); err != nil {
+		diags.AddError("Invalid private state", "Could not parse the private state of the endpoint: "+err.Error())
+	}
+
+	return privateState, diags
+}
+
+func setPrivateState(ctx context.Context, private privateStateSetter, privateState endpointPrivateState) diag.Diagnostics {
+	value, err := json.Marshal(privateState)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", "Could not serialize the private state of the endpoint: "+err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, PRIVATE_STATE_KEY, value)
+}
+
+func (s endpointPrivateState) shouldCreateQueue() bool {
+	return !s.QueueExists
+}
+
+// shouldCreateEndpoint is false without subscriptions, as an endpoint is only created for them.
+func (s endpointPrivateState) shouldCreateEndpoint(model endpointResourceModel) bool {
+	return !s.EndpointExists && len(model.Subscriptions) > 0
+}
+
+// existingRules returns false, when the rules were not read yet.
+func (s endpointPrivateState) existingRules() ([]asb.AsbSubscriptionRule, bool) {
+	if s.Rules == nil {
+		return nil, false
+	}
+
+	rules := make([]asb.AsbSubscriptionRule, len(s.Rules))
+	for i, rule := range s.Rules {
+		rules[i] = asb.AsbSubscriptionRule{Name: rule.Name, Filter: rule.Filter, FilterType: rule.FilterType}
+	}
+
+	return rules, true
+}
+
+func toPrivateRules(rules []asb.AsbSubscriptionRule) []endpointPrivateRule {
+	privateRules := make([]endpointPrivateRule, len(rules))
+	for i, rule := range rules {
+		privateRules[i] = endpointPrivateRule{Name: rule.Name, Filter: rule.Filter, FilterType: rule.FilterType}
+	}
+
+	return privateRules
+}
@@ -20,16 +20,35 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	r, ok := r.forNamespace(state.Namespace, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, DEFAULT_READ_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	defer addTimeoutError(ctx, &resp.Diagnostics, "read", state.ToAsbModel(), readTimeout)
+
 	previousState := state
+	previousPrivateState, diags := getPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	state.ShouldUpdateSubscriptions = types.BoolValue(false)
-	state.HasMalformedFilters = types.BoolValue(false)
+	privateState := previousPrivateState
 
-	if !r.syncQueueState(ctx, &previousState, &state, resp) {
+	if !r.syncQueueState(ctx, &previousState, previousPrivateState, &state, &privateState, resp) {
 		return
 	}
 
-	if !r.syncSubscriptionState(ctx, &previousState, &state, resp) {
+	if !r.syncSubscriptionState(ctx, &previousState, previousPrivateState, &state, &privateState, resp) {
 		return
 	}
 
@@ -41,12 +60,16 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setPrivateState(ctx, resp.Private, privateState)...)
 }
 
 func (r *endpointResource) syncQueueState(
 	ctx context.Context,
 	previousState *endpointResourceModel,
+	previousPrivateState endpointPrivateState,
 	updatedState *endpointResourceModel,
+	updatedPrivateState *endpointPrivateState,
 	resp *resource.ReadResponse,
 ) bool {
 	queue, err := r.client.GetEndpointQueue(ctx, previousState.ToAsbModel())
@@ -59,25 +82,23 @@ func (r *endpointResource) syncQueueState(
 	}
 
 	queueExistsInAsb := queue != nil
-	terraformPreviouslyCreatedQueue := previousState.QueueExists.ValueBool()
+	terraformPreviouslyCreatedQueue := previousPrivateState.QueueExists
 	endpointName := previousState.EndpointName.ValueString()
 
 	if terraformPreviouslyCreatedQueue {
 		if !queueExistsInAsb {
 			resp.Diagnostics.AddWarning(fmt.Sprintf("The queue for endpoint %v exists in Terraform state but not in Azure Service Bus.", endpointName),
 				"This could indicate that someone manually deleted it. It will be recreated on the next apply.")
-			updatedState.ShouldCreateQueue = types.BoolValue(true)
-			updatedState.QueueExists = types.BoolValue(false)
+			applyMissingQueueToState(updatedState, updatedPrivateState)
 			return true
 		}
 
-		applyAsbQueueStateToState(updatedState, queue)
+		applyAsbQueueStateToState(updatedState, updatedPrivateState, queue)
 		return true
 	}
 
 	if !queueExistsInAsb {
-		updatedState.QueueExists = types.BoolValue(false)
-		updatedState.ShouldCreateQueue = types.BoolValue(true)
+		applyMissingQueueToState(updatedState, updatedPrivateState)
 		return true // Wasn't created yet, what we expect
 	}
 
@@ -87,16 +108,27 @@ func (r *endpointResource) syncQueueState(
 			"If you did not intend to import this endpoint, you can remove it from the Terraform state using `terraform state rm`, or you can contact the platform for support.",
 	)
 
-	applyAsbQueueStateToState(updatedState, queue)
+	applyAsbQueueStateToState(updatedState, updatedPrivateState, queue)
 	return true
 }
 
+// applyMissingQueueToState clears the queue options, such that the plan shows the queue to be created.
+func applyMissingQueueToState(state *endpointResourceModel, privateState *endpointPrivateState) {
+	privateState.QueueExists = false
+
+	state.QueueOptions = endpointResourceQueueOptionsModel{
+		EnablePartitioning:        types.BoolNull(),
+		MaxSizeInMegabytes:        types.Int64Null(),
+		MaxMessageSizeInKilobytes: types.Int64Null(),
+	}
+}
+
 func applyAsbQueueStateToState(
 	state *endpointResourceModel,
+	privateState *endpointPrivateState,
 	queue *admin.GetQueueResponse,
 ) {
-	state.QueueExists = types.BoolValue(true)
-	state.ShouldCreateQueue = types.BoolValue(false)
+	privateState.QueueExists = true
 
 	maxQueueSizeInMb := *queue.QueueProperties.MaxSizeInMegabytes
 	partitioningIsEnabled := *queue.QueueProperties.EnablePartitioning
@@ -117,7 +149,9 @@ func applyAsbQueueStateToState(
 func (r *endpointResource) syncSubscriptionState(
 	ctx context.Context,
 	previousState *endpointResourceModel,
+	previousPrivateState endpointPrivateState,
 	updatedState *endpointResourceModel,
+	updatedPrivateState *endpointPrivateState,
 	resp *resource.ReadResponse,
 ) bool {
 	endpointExists, err := r.client.EndpointExists(ctx, previousState.ToAsbModel())
@@ -130,25 +164,22 @@ func (r *endpointResource) syncSubscriptionState(
 		return false
 	}
 
-	terraformPreviouslyCreatedEndpoint := previousState.EndpointExists.ValueBool()
+	terraformPreviouslyCreatedEndpoint := previousPrivateState.EndpointExists
 
 	if terraformPreviouslyCreatedEndpoint {
 		if !endpointExists {
 			resp.Diagnostics.AddWarning(fmt.Sprintf("Endpoint %v exists in Terraform state but not in Azure Service Bus.", previousState.EndpointName.ValueString()),
 				"This could indicate that someone manually deleted it. It will be recreated on next apply.")
-			updatedState.ShouldCreateEndpoint = types.BoolValue(true)
-			updatedState.EndpointExists = types.BoolValue(false)
+			applyMissingEndpointToState(updatedState, updatedPrivateState)
 			return true
 		}
 
-		updatedState.ShouldCreateEndpoint = types.BoolValue(false)
-		updatedState.EndpointExists = types.BoolValue(true)
-		return r.updateEndpointSubscriptionState(ctx, updatedState, resp)
+		updatedPrivateState.EndpointExists = true
+		return r.updateEndpointSubscriptionState(ctx, updatedState, updatedPrivateState, resp)
 	}
 
 	if !endpointExists {
-		updatedState.EndpointExists = types.BoolValue(false)
-		updatedState.ShouldCreateEndpoint = types.BoolValue(true)
+		applyMissingEndpointToState(updatedState, updatedPrivateState)
 		return true // Wasn't created yet, what we expect
 	}
 
@@ -158,15 +189,22 @@ func (r *endpointResource) syncSubscriptionState(
 			"If you did not intend to import this endpoint, you can remove it from the Terraform state using `terraform state rm`, or you can contact the platform for support.",
 	)
 
-	updatedState.EndpointExists = types.BoolValue(true)
-	updatedState.ShouldCreateEndpoint = types.BoolValue(false)
+	updatedPrivateState.EndpointExists = true
+
+	return r.updateEndpointSubscriptionState(ctx, updatedState, updatedPrivateState, resp)
+}
 
-	return r.updateEndpointSubscriptionState(ctx, updatedState, resp)
+// applyMissingEndpointToState clears the subscriptions, such that the plan shows them to be created with the endpoint.
+func applyMissingEndpointToState(state *endpointResourceModel, privateState *endpointPrivateState) {
+	privateState.EndpointExists = false
+	privateState.Rules = []endpointPrivateRule{}
+	state.Subscriptions = []SubscriptionModel{}
 }
 
 func (r *endpointResource) updateEndpointSubscriptionState(
 	ctx context.Context,
 	updatedState *endpointResourceModel,
+	updatedPrivateState *endpointPrivateState,
 	resp *resource.ReadResponse,
 ) bool {
 	azureSubscriptions, err := r.client.GetAsbSubscriptionsRules(ctx, updatedState.ToAsbModel())
@@ -174,6 +212,9 @@ func (r *endpointResource) updateEndpointSubscriptionState(
 		return false
 	}
 
+	// The plan is made against the rules with their actual names
+	updatedPrivateState.Rules = toPrivateRules(azureSubscriptions)
+
 	subscriptionFilterValues := []string{}
 	for _, subscription := range updatedState.Subscriptions {
 		subscriptionFilterValues = append(subscriptionFilterValues, subscription.Filter.ValueString())
@@ -199,7 +240,12 @@ func (r *endpointResource) updateEndpointSubscriptionState(
 			resp.Diagnostics.AddWarning(fmt.Sprintf("Cannot parse rule '%v' in Subscription %v for endpoint %v", azureSubscription.Filter, azureSubscription.Name, updatedState.EndpointName),
 				"This could indicate that someone manually added the rule it. It will be added to the state as is.",
 			)
-			updatedState.HasMalformedFilters = types.BoolValue(true)
+			// The plan shows the correction of the rule
+			updatedSubscriptionState = append(updatedSubscriptionState, SubscriptionModel{
+				Filter:     basetypes.NewStringValue(azureSubscription.Filter),
+				FilterType: basetypes.NewStringValue(azureSubscription.FilterType),
+			})
+			continue
 		}
 
 		tflog.Info(ctx, fmt.Sprintf("Subscription %s is in state as %s of type %s", azureSubscription.Name, subscription.Filter.ValueString(), subscription.FilterType.ValueString()))
@@ -0,0 +1,53 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const DEFAULT_CREATE_TIMEOUT = 30 * time.Minute
+const DEFAULT_READ_TIMEOUT = 10 * time.Minute
+const DEFAULT_UPDATE_TIMEOUT = 30 * time.Minute
+const DEFAULT_DELETE_TIMEOUT = 30 * time.Minute
+
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// addTimeoutError explains which operation of which endpoint ran out of time. The errors of
+// the single calls only name the entity they were working on.
+func addTimeoutError(
+	ctx context.Context,
+	diagnostics *diag.Diagnostics,
+	operation string,
+	model asb.AsbEndpointModel,
+	timeout time.Duration,
+) {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+
+	diagnostics.AddError(
+		fmt.Sprintf("Timeout during %s of endpoint", operation),
+		fmt.Sprintf(
+			"The %s of endpoint %s on topic %s did not finish within %s. "+
+				"If it regularly takes longer, increase the %s value in the timeouts block of the resource.",
+			operation, model.EndpointName, model.TopicName, timeout, operation,
+		),
+	)
+}
@@ -0,0 +1,220 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/exp/slices"
+)
+
+const ROLLBACK_TIMEOUT = 10 * time.Minute
+
+// endpointTransaction records the steps of a create or update, which succeeded in Service Bus. When the apply fails
+// midway, the state shows exactly what exists, or the steps are undone in reverse order with rollback_on_failure.
+type endpointTransaction struct {
+	state        endpointResourceModel // What existed before the first step
+	privateState endpointPrivateState
+	completed    []endpointTransactionStep
+}
+
+type endpointTransactionStep struct {
+	description string
+	apply       func(state *endpointResourceModel, privateState *endpointPrivateState) // Records the step in the state
+	undo        func(ctx context.Context) error                                        // Nil, when nothing was changed in Service Bus
+}
+
+func newEndpointTransaction(state endpointResourceModel, privateState endpointPrivateState) *endpointTransaction {
+	return &endpointTransaction{state: state, privateState: privateState}
+}
+
+func (t *endpointTransaction) complete(step endpointTransactionStep) {
+	t.completed = append(t.completed, step)
+}
+
+func (t *endpointTransaction) isEmpty() bool {
+	return len(t.completed) == 0
+}
+
+// hasChanges is false, when the completed steps only took over what existed before.
+func (t *endpointTransaction) hasChanges() bool {
+	return slices.ContainsFunc(t.completed, func(step endpointTransactionStep) bool { return step.undo != nil })
+}
+
+// result returns the state with all completed steps, which were not undone.
+func (t *endpointTransaction) result() (endpointResourceModel, endpointPrivateState) {
+	state := t.state
+	state.Subscriptions = slices.Clone(t.state.Subscriptions)
+	state.AdditionalQueues = slices.Clone(t.state.AdditionalQueues)
+	privateState := t.privateState
+	privateState.Rules = slices.Clone(t.privateState.Rules)
+
+	for _, step := range t.completed {
+		step.apply(&state, &privateState)
+	}
+
+	return state, privateState
+}
+
+// rollback undoes the completed steps in reverse order. It stops at the first step, which cannot be undone, as
+// the steps before depend on it, like the queue the subscription forwards to.
+func (t *endpointTransaction) rollback(ctx context.Context) error {
+	for len(t.completed) > 0 {
+		step := t.completed[len(t.completed)-1]
+		if step.undo != nil {
+			tflog.Info(ctx, "Rolling back: "+step.description)
+			if err := step.undo(ctx); err != nil {
+				return fmt.Errorf("rolling back %s failed: %w", step.description, err)
+			}
+		}
+
+		t.completed = t.completed[:len(t.completed)-1]
+	}
+
+	return nil
+}
+
+// describe lists the completed steps for the diagnostics of a failed apply.
+func (t *endpointTransaction) describe() string {
+	if t.isEmpty() {
+		return "No changes were made in Service Bus."
+	}
+
+	descriptions := make([]string, len(t.completed))
+	for i, step := range t.completed {
+		descriptions[i] = "  " + step.description
+	}
+
+	return "These changes were made in Service Bus:\n" + strings.Join(descriptions, "\n")
+}
+
+// fail either rolls back the transaction or keeps its completed steps. It returns false, when nothing is
+// left in Service Bus, which must be recorded in the state. Taken over queues are not, as recording them on
+// a failed create would taint the endpoint, whose replacement deletes them.
+func (t *endpointTransaction) fail(ctx context.Context, rollbackOnFailure types.Bool, diagnostics *diag.Diagnostics) bool {
+	if !t.hasChanges() {
+		return false
+	}
+
+	if !rollbackOnFailure.ValueBool() {
+		diagnostics.AddWarning(
+			"Endpoint partially applied",
+			t.describe()+"\n\nThe state records them. The next apply replaces a partially created endpoint and continues a partial update. "+
+				"Set rollback_on_failure to undo them instead.",
+		)
+		return true
+	}
+
+	// The apply might have failed, because it ran out of time
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ROLLBACK_TIMEOUT)
+	defer cancel()
+
+	err := t.rollback(ctx)
+	if err != nil {
+		diagnostics.AddError(
+			"Error rolling back endpoint",
+			err.Error()+"\n\n"+t.describe()+"\n\nThe state records the changes, which were not rolled back.",
+		)
+		return t.hasChanges()
+	}
+
+	diagnostics.AddWarning("Endpoint rolled back", "All changes of the failed apply were undone in Service Bus.")
+	return false
+}
+
+// queueStep records the queue of the endpoint. Without undo the queue existed before and is taken over.
+func queueStep(model asb.AsbEndpointModel, options endpointResourceQueueOptionsModel, undo func(ctx context.Context) error) endpointTransactionStep {
+	return endpointTransactionStep{
+		description: describeQueueStep("queue", model.EndpointName, undo),
+		apply: func(state *endpointResourceModel, privateState *endpointPrivateState) {
+			state.QueueOptions = options
+			privateState.QueueExists = true
+		},
+		undo: undo,
+	}
+}
+
+func additionalQueueStep(queue string, undo func(ctx context.Context) error) endpointTransactionStep {
+	return endpointTransactionStep{
+		description: describeQueueStep("additional queue", queue, undo),
+		apply: func(state *endpointResourceModel, _ *endpointPrivateState) {
+			state.AdditionalQueues = append(state.AdditionalQueues, queue)
+		},
+		undo: undo,
+	}
+}
+
+func describeQueueStep(kind string, queue string, undo func(ctx context.Context) error) string {
+	if undo == nil {
+		return fmt.Sprintf("took over existing %s %s", kind, queue)
+	}
+
+	return fmt.Sprintf("created %s %s", kind, queue)
+}
+
+func endpointStep(model asb.AsbEndpointModel, undo func(ctx context.Context) error) endpointTransactionStep {
+	return endpointTransactionStep{
+		description: fmt.Sprintf("created subscription %s on topic %s", model.EndpointName, model.TopicName),
+		apply: func(_ *endpointResourceModel, privateState *endpointPrivateState) {
+			privateState.EndpointExists = true
+		},
+		undo: undo,
+	}
+}
+
+// ruleStep replaces the subscription of the rule in the state by the result of the operation. The subscription is
+// found by its rule name, or as Read put a malformed or foreign rule into the state, by the existing rule.
+// The rule in the private state is replaced by its name.
+func ruleStep(operation asb.AsbRuleOperation, existing []asb.AsbSubscriptionRule, undo func(ctx context.Context) error) endpointTransactionStep {
+	existingRule := asb.AsbSubscriptionRule{}
+	for _, rule := range existing {
+		if rule.Name == operation.RuleName {
+			existingRule = rule
+		}
+	}
+
+	return endpointTransactionStep{
+		description: fmt.Sprintf("%s of rule %s", operation.Type, operation.RuleName),
+		apply: func(state *endpointResourceModel, privateState *endpointPrivateState) {
+			subscriptions := []SubscriptionModel{}
+			for _, subscription := range state.Subscriptions {
+				isRule := asb.GetSubscriptionRuleName(subscription.Filter.ValueString()) == operation.RuleName ||
+					(existingRule.Name != "" &&
+						subscription.Filter.ValueString() == existingRule.Filter &&
+						subscription.FilterType.ValueString() == existingRule.FilterType)
+				if !isRule {
+					subscriptions = append(subscriptions, subscription)
+				}
+			}
+
+			if operation.Type != asb.RULE_OPERATION_DELETE {
+				subscriptions = append(subscriptions, SubscriptionModel{
+					Filter:     types.StringValue(operation.Subscription.Filter),
+					FilterType: types.StringValue(operation.Subscription.FilterType),
+				})
+			}
+
+			state.Subscriptions = subscriptions
+
+			if privateState.Rules == nil {
+				return // The rules were not read yet
+			}
+			rules := []endpointPrivateRule{}
+			for _, rule := range privateState.Rules {
+				if rule.Name != operation.RuleName {
+					rules = append(rules, rule)
+				}
+			}
+			if operation.Type != asb.RULE_OPERATION_DELETE {
+				rules = append(rules, toPrivateRules([]asb.AsbSubscriptionRule{asb.GetExpectedAsbSubscriptionRule(operation.Subscription)})...)
+			}
+			privateState.Rules = rules
+		},
+		undo: undo,
+	}
+}
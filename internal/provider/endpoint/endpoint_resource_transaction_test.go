@@ -0,0 +1,222 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func subscription(filter string, filterType string) SubscriptionModel {
+	return SubscriptionModel{Filter: types.StringValue(filter), FilterType: types.StringValue(filterType)}
+}
+
+// undoStep returns a step, which records its undo in the undone steps and fails with the error.
+func undoStep(description string, undone *[]string, err error) endpointTransactionStep {
+	return endpointTransactionStep{
+		description: description,
+		apply:       func(_ *endpointResourceModel, _ *endpointPrivateState) {},
+		undo: func(_ context.Context) error {
+			*undone = append(*undone, description)
+			return err
+		},
+	}
+}
+
+func takeOverStep(description string) endpointTransactionStep {
+	return endpointTransactionStep{
+		description: description,
+		apply:       func(_ *endpointResourceModel, _ *endpointPrivateState) {},
+	}
+}
+
+func TestEndpointTransaction_Result(t *testing.T) {
+	model := asb.AsbEndpointModel{TopicName: "bundle-1", EndpointName: "endpoint"}
+	existing := endpointResourceModel{AdditionalQueues: []string{}, Subscriptions: []SubscriptionModel{}}
+	transaction := newEndpointTransaction(existing, endpointPrivateState{Rules: []endpointPrivateRule{}})
+
+	transaction.complete(queueStep(model, endpointResourceQueueOptionsModel{EnablePartitioning: types.BoolValue(true)}, nil))
+	transaction.complete(additionalQueueStep("endpoint-audit", nil))
+	transaction.complete(endpointStep(model, nil))
+	transaction.complete(ruleStep(
+		asb.AsbRuleOperation{Type: asb.RULE_OPERATION_CREATE, RuleName: "Dg.Test.V1.Created", Subscription: asb.AsbSubscriptionModel{Filter: "Dg.Test.V1.Created", FilterType: "correlation"}},
+		nil,
+		nil,
+	))
+
+	state, privateState := transaction.result()
+
+	assert.Equal(t, types.BoolValue(true), state.QueueOptions.EnablePartitioning)
+	assert.Equal(t, []string{"endpoint-audit"}, state.AdditionalQueues)
+	assert.Equal(t, []SubscriptionModel{subscription("Dg.Test.V1.Created", "correlation")}, state.Subscriptions)
+	assert.Equal(t, endpointPrivateState{
+		QueueExists:    true,
+		EndpointExists: true,
+		Rules:          []endpointPrivateRule{{Name: "Dg.Test.V1.Created", Filter: "Dg.Test.V1.Created", FilterType: "correlation"}},
+	}, privateState)
+
+	again, _ := transaction.result()
+	assert.Equal(t, state, again, "the steps are applied to a copy of the existing state")
+	assert.Empty(t, transaction.privateState.Rules, "the existing private state is not changed")
+}
+
+func TestRuleStep_ReplacesMalformedRules(t *testing.T) {
+	malformed := asb.AsbSubscriptionRule{
+		Name:       asb.GetSubscriptionRuleName("Dg.Test.V1.Malformed"),
+		Filter:     "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.Test.V1.Malformed%' OR 1=1",
+		FilterType: "sql",
+	}
+	existing := []asb.AsbSubscriptionRule{malformed}
+	transaction := newEndpointTransaction(
+		endpointResourceModel{Subscriptions: []SubscriptionModel{
+			subscription(malformed.Filter, "sql"),
+			subscription("Dg.Test.V1.Kept", "correlation"),
+		}},
+		endpointPrivateState{Rules: toPrivateRules(existing)},
+	)
+
+	transaction.complete(ruleStep(
+		asb.AsbRuleOperation{Type: asb.RULE_OPERATION_UPDATE, RuleName: malformed.Name, Subscription: asb.AsbSubscriptionModel{Filter: "Dg.Test.V1.Malformed", FilterType: "sql"}},
+		existing,
+		nil,
+	))
+	state, privateState := transaction.result()
+
+	assert.Equal(t, []SubscriptionModel{
+		subscription("Dg.Test.V1.Kept", "correlation"),
+		subscription("Dg.Test.V1.Malformed", "sql"),
+	}, state.Subscriptions)
+	assert.Equal(t, toPrivateRules([]asb.AsbSubscriptionRule{
+		asb.GetExpectedAsbSubscriptionRule(asb.AsbSubscriptionModel{Filter: "Dg.Test.V1.Malformed", FilterType: "sql"}),
+	}), privateState.Rules)
+}
+
+func TestRuleStep_DeletesForeignRules(t *testing.T) {
+	existing := []asb.AsbSubscriptionRule{{Name: "manual", Filter: "1=1", FilterType: "sql"}}
+	transaction := newEndpointTransaction(
+		endpointResourceModel{Subscriptions: []SubscriptionModel{subscription("1=1", "sql")}},
+		endpointPrivateState{Rules: toPrivateRules(existing)},
+	)
+
+	transaction.complete(ruleStep(
+		asb.AsbRuleOperation{Type: asb.RULE_OPERATION_DELETE, RuleName: "manual", Subscription: asb.AsbSubscriptionModel{Filter: "1=1", FilterType: "sql"}},
+		existing,
+		nil,
+	))
+	state, privateState := transaction.result()
+
+	assert.Empty(t, state.Subscriptions)
+	assert.Empty(t, privateState.Rules)
+}
+
+func TestRuleStep_KeepsRulesUnread(t *testing.T) {
+	transaction := newEndpointTransaction(endpointResourceModel{}, endpointPrivateState{})
+
+	transaction.complete(ruleStep(
+		asb.AsbRuleOperation{Type: asb.RULE_OPERATION_CREATE, RuleName: "Dg.Test.V1.Created", Subscription: asb.AsbSubscriptionModel{Filter: "Dg.Test.V1.Created", FilterType: "sql"}},
+		nil,
+		nil,
+	))
+	_, privateState := transaction.result()
+
+	assert.Nil(t, privateState.Rules, "a single rule does not tell which other rules exist")
+}
+
+func TestEndpointTransaction_RollbackStopsAtTheFirstFailure(t *testing.T) {
+	undone := []string{}
+	transaction := newEndpointTransaction(endpointResourceModel{}, endpointPrivateState{})
+	transaction.complete(undoStep("created queue", &undone, nil))
+	transaction.complete(undoStep("created subscription", &undone, errors.New("forbidden")))
+	transaction.complete(takeOverStep("took over additional queue"))
+	transaction.complete(undoStep("create of rule", &undone, nil))
+
+	err := transaction.rollback(context.Background())
+
+	assert.ErrorContains(t, err, "rolling back created subscription failed: forbidden")
+	assert.Equal(t, []string{"create of rule", "created subscription"}, undone)
+	assert.Equal(t, "These changes were made in Service Bus:\n  created queue\n  created subscription", transaction.describe())
+}
+
+func TestEndpointTransaction_Fail(t *testing.T) {
+	tests := []struct {
+		name              string
+		steps             func(undone *[]string) []endpointTransactionStep
+		rollbackOnFailure bool
+		recorded          bool
+		warning           string
+		error             string
+		undone            []string
+	}{
+		{
+			name:   "without steps",
+			steps:  func(_ *[]string) []endpointTransactionStep { return nil },
+			undone: []string{},
+		},
+		{
+			name: "with taken over queues only",
+			steps: func(_ *[]string) []endpointTransactionStep {
+				return []endpointTransactionStep{takeOverStep("took over existing queue endpoint")}
+			},
+			undone: []string{},
+		},
+		{
+			name: "without rollback",
+			steps: func(undone *[]string) []endpointTransactionStep {
+				return []endpointTransactionStep{undoStep("created queue endpoint", undone, nil)}
+			},
+			recorded: true,
+			warning:  "Endpoint partially applied",
+			undone:   []string{},
+		},
+		{
+			name: "with rollback",
+			steps: func(undone *[]string) []endpointTransactionStep {
+				return []endpointTransactionStep{undoStep("created queue endpoint", undone, nil)}
+			},
+			rollbackOnFailure: true,
+			warning:           "Endpoint rolled back",
+			undone:            []string{"created queue endpoint"},
+		},
+		{
+			name: "with failing rollback",
+			steps: func(undone *[]string) []endpointTransactionStep {
+				return []endpointTransactionStep{undoStep("created queue endpoint", undone, errors.New("forbidden"))}
+			},
+			rollbackOnFailure: true,
+			recorded:          true,
+			error:             "Error rolling back endpoint",
+			undone:            []string{"created queue endpoint"},
+		},
+	}
+
+	for _, test := range tests {
+		undone := []string{}
+		transaction := newEndpointTransaction(endpointResourceModel{}, endpointPrivateState{})
+		for _, step := range test.steps(&undone) {
+			transaction.complete(step)
+		}
+
+		var diagnostics diag.Diagnostics
+		recorded := transaction.fail(context.Background(), types.BoolValue(test.rollbackOnFailure), &diagnostics)
+
+		assert.Equal(t, test.recorded, recorded, test.name)
+		assert.Equal(t, test.undone, undone, test.name)
+		summaries := []string{}
+		for _, diagnostic := range diagnostics {
+			summaries = append(summaries, diagnostic.Summary())
+		}
+		expected := []string{}
+		if test.warning != "" {
+			expected = append(expected, test.warning)
+		}
+		if test.error != "" {
+			expected = append(expected, test.error)
+		}
+		assert.Equal(t, expected, summaries, test.name)
+		assert.Equal(t, test.error != "", diagnostics.HasError(), test.name)
+	}
+}
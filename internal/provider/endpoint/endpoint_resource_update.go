@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"golang.org/x/exp/slices"
 )
 
 func (r *endpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -16,182 +15,142 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r, ok := r.forNamespace(plan.Namespace, &resp.Diagnostics)
+	if !ok {
+		return
+	}
 	planModel := plan.ToAsbModel()
 
-	previousState := endpointResourceModel{}
-	req.State.Get(ctx, &previousState)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_UPDATE_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	defer addTimeoutError(ctx, &resp.Diagnostics, "update", planModel, updateTimeout)
+
+	var state endpointResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	privateState, diags := getPrivateState(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every step, which succeeded, is recorded, such that a failure leaves an accurate state or is rolled back
+	transaction := newEndpointTransaction(state, privateState)
 
-	if plan.ShouldCreateQueue.ValueBool() {
+	if privateState.shouldCreateQueue() {
 		err := r.client.CreateEndpointQueue(ctx, planModel.EndpointName, planModel.QueueOptions)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating queue",
 				"Queue creation failed with error: "+err.Error(),
 			)
+			r.failUpdate(ctx, transaction, plan, resp)
 			return
 		}
+		transaction.complete(queueStep(planModel, plan.QueueOptions, func(ctx context.Context) error {
+			return r.client.DeleteEndpointQueue(ctx, planModel)
+		}))
 	}
 
-	if plan.ShouldCreateEndpoint.ValueBool() {
+	if privateState.shouldCreateEndpoint(plan) {
 		err := r.client.CreateEndpointWithDefaultRule(ctx, planModel)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error creating endpoint",
 				"Endpoint creation failed with error: "+err.Error(),
 			)
+			r.failUpdate(ctx, transaction, plan, resp)
 			return
 		}
+		transaction.complete(endpointStep(planModel, func(ctx context.Context) error {
+			return r.client.DeleteEndpoint(ctx, planModel)
+		}))
 	}
 
-	err := r.UpdateSubscriptions(
-		ctx,
-		previousState,
-		plan,
-		resp,
-	)
-	if err != nil {
+	// Malformed subscriptions, which Read puts into the state as they are in Service Bus, are corrected by the same reconciliation
+	for _, err := range r.UpdateSubscriptions(ctx, plan, transaction) {
 		resp.Diagnostics.AddError(
 			"Error updating subscriptions",
 			"Subscription update failed with error: "+err.Error(),
 		)
+	}
+	if resp.Diagnostics.HasError() {
+		r.failUpdate(ctx, transaction, plan, resp)
 		return
 	}
 
-	if plan.ShouldUpdateSubscriptions.ValueBool() {
-		err := r.updateMalformedSubscriptions(ctx, plan)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error updating subscriptions",
-				"Subscription update failed with error: "+err.Error(),
-			)
-			return
-		}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	_, privateState = transaction.result()
+	privateState.QueueExists = true
+	privateState.EndpointExists = privateState.EndpointExists || len(plan.Subscriptions) > 0
+	resp.Diagnostics.Append(setPrivateState(ctx, resp.Private, privateState)...)
 }
 
-func (r *endpointResource) UpdateSubscriptions(
+// failUpdate records the completed steps in the state, as the framework would keep the prior state otherwise.
+// With rollback_on_failure the steps are undone and the prior state remains.
+func (r *endpointResource) failUpdate(
 	ctx context.Context,
-	previousState endpointResourceModel,
+	transaction *endpointTransaction,
 	plan endpointResourceModel,
 	resp *resource.UpdateResponse,
-) error {
-	planModel := plan.ToAsbModel()
-	// This is deliberately done in this order, such that if subscriptions are replaced,
-	// the new subscriptions are created before the old ones are deleted, thus avoiding
-	// the Endpoint missing events for a short period of time.
-
-	// Create subscriptions that are not in the previous state
-	for _, planSubscription := range plan.Subscriptions {
-		tflog.Info(ctx, fmt.Sprintf("Checking subscription create %s", planSubscription))
-		hasChanged := !slices.Contains(previousState.Subscriptions, planSubscription)
-		if !hasChanged {
-			// Exists and should stay like that
-			continue
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Checking subscription exists %s", planSubscription))
-		// Rule does not exist, create it
-		rule, err := r.client.GetAsbSubscriptionRule(ctx, planModel, planSubscription.Filter.ValueString())
-		if err != nil {
-			tflog.Info(ctx, fmt.Sprintf("Creating subscription %s", planSubscription))
-			err := r.client.CreateAsbSubscriptionRule(ctx, planModel, planSubscription.ToAsbModel())
-			if err == nil {
-				continue
-			}
-
-			return err
-		}
-
-		if rule.FilterType != planSubscription.FilterType.ValueString() {
-			// Rule exists, update it
-			tflog.Info(ctx, fmt.Sprintf("Updating subscription function filter type %s", planSubscription))
-			err := r.client.UpdateAsbSubscriptionRule(ctx, planModel, planSubscription.ToAsbModel())
-			if err == nil {
-				continue
-			}
-
-			return err
-		}
-
-		tflog.Error(ctx, "Subscription already exists and nothing changed. This should not happen.")
-	}
-
-	// Delete subscriptions that are not in the plan
-	planSubscriptionFilterValues := []string{}
-	for _, subscription := range plan.Subscriptions {
-		planSubscriptionFilterValues = append(planSubscriptionFilterValues, subscription.Filter.ValueString())
-	}
-
-	for _, previousSubscription := range previousState.Subscriptions {
-		tflog.Info(ctx, fmt.Sprintf("Checking subscription delete %s", previousSubscription))
-		// The rule name is created from the filter value, so we can use it to check if the rule exists
-		shouldBeDeleted := !slices.Contains(planSubscriptionFilterValues, previousSubscription.Filter.ValueString())
-		if !shouldBeDeleted {
-			continue
-		}
-
-		tflog.Info(ctx, fmt.Sprintf("Deleting subscription %s", previousSubscription))
-		err := r.client.DeleteAsbSubscriptionRule(ctx, planModel, previousSubscription.ToAsbModel())
-		if err != nil {
-			return err
-		}
-
-		_, err = r.client.GetAsbSubscriptionRule(ctx, planModel, previousSubscription.Filter.ValueString())
-		if err != nil {
-			// Deals with an edge case where the subscription was not correctly identified
-			// by the read operation, due to a failed update before this one.
-			tflog.Info(ctx, fmt.Sprintf(
-				"Subscription %s was already deleted by a previous update operation that was not persisted in state",
-				previousSubscription,
-			))
-			continue
-		}
-
-		return err
+) {
+	if !transaction.fail(ctx, plan.RollbackOnFailure, &resp.Diagnostics) {
+		return
 	}
 
-	return nil
+	state, privateState := transaction.result()
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(setPrivateState(ctx, resp.Private, privateState)...)
 }
 
-func (r *endpointResource) updateMalformedSubscriptions(
+func (r *endpointResource) UpdateSubscriptions(
 	ctx context.Context,
 	plan endpointResourceModel,
-) error {
-	azureSubscription, err := r.client.GetAsbSubscriptionsRules(ctx, plan.ToAsbModel())
-	if err != nil {
-		return err
-	}
+	transaction *endpointTransaction,
+) []error {
+	planModel := plan.ToAsbModel()
 
-	plannedSubscriptions := []string{}
-	for _, subscription := range plan.Subscriptions {
-		plannedSubscriptions = append(plannedSubscriptions, subscription.Filter.ValueString())
+	// Without subscriptions no endpoint is created, thus there are no rules to list
+	if len(planModel.Subscriptions) == 0 && !transaction.privateState.EndpointExists {
+		return nil
 	}
 
-	for _, subscription := range azureSubscription {
-		tflog.Info(ctx, fmt.Sprintf("Checking subscription update %s", subscription.Name))
-
-		index := asb.GetSubscriptionFilterValueForAsbRuleName(plannedSubscriptions, subscription)
-		if index < 0 {
-			// This most likely means that the subscription will be deleted
-			tflog.Info(ctx, fmt.Sprintf("Subscription %s not found in plan", subscription.Name))
-			continue
-		}
+	existingRules, err := r.client.GetAsbSubscriptionsRules(ctx, planModel)
+	if statusCodeIsOk(err) {
+		// The endpoint was deleted since it was read, thus it has no rules
+		existingRules, err = []asb.AsbSubscriptionRule{}, nil
+	}
+	if err != nil {
+		return []error{fmt.Errorf("listing subscription rules failed: %w", err)}
+	}
 
-		plannedSubscription := plan.Subscriptions[index]
+	// The rule steps are recorded against the rules, which exist before the first of them
+	transaction.privateState.Rules = toPrivateRules(existingRules)
 
-		if asb.IsAsbSubscriptionRuleCorrect(subscription, plannedSubscription.ToAsbModel()) {
-			tflog.Info(ctx, fmt.Sprintf("Subscription %s is correct", subscription.Name))
-			continue
-		}
+	// The plan creates the new subscriptions before the old ones are deleted, thus avoiding
+	// the Endpoint missing events for a short period of time.
+	rulePlan := asb.PlanAsbSubscriptionRules(planModel.Subscriptions, existingRules)
+	for _, operation := range rulePlan.Operations() {
+		tflog.Info(ctx, fmt.Sprintf("Planned %s of subscription rule %s", operation.Type, operation.RuleName))
+	}
 
-		tflog.Info(ctx, fmt.Sprintf("Subscription %s is incorrect. Updating.", subscription.Name))
-		err := r.client.UpdateAsbSubscriptionRule(ctx, plan.ToAsbModel(), plannedSubscription.ToAsbModel())
-		if err != nil {
-			return err
-		}
+	completed, errs := r.client.ApplyAsbRulePlan(ctx, planModel, rulePlan)
+	for _, operation := range completed {
+		operation := operation
+		transaction.complete(ruleStep(operation, existingRules, func(ctx context.Context) error {
+			return r.client.RevertAsbRuleOperation(ctx, planModel, operation, existingRules)
+		}))
 	}
 
-	return nil
+	return errs
 }
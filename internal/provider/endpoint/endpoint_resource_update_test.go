@@ -0,0 +1,86 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEndpointResource returns a resource of a namespace, which answers every request with the status code.
+func newTestEndpointResource(t *testing.T, statusCode int) (*endpointResource, func() []string) {
+	var mutex sync.Mutex
+	requests := []string{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mutex.Unlock()
+
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	client, err := az.NewClientFromConnectionString(
+		"Endpoint=sb://"+host+"/;SharedAccessKeyName=Manage;SharedAccessKey=key",
+		&az.ClientOptions{ClientOptions: azcore.ClientOptions{
+			Transport: server.Client(),
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		}},
+	)
+	require.NoError(t, err)
+
+	wrapper := &asb.AsbClientWrapper{
+		Client:   client,
+		Hostname: host,
+		Cache:    asb.NewNamespaceCache(),
+		Retry:    asb.RetryOptions{MaxAttempts: 1},
+	}
+	return &endpointResource{client: wrapper}, func() []string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]string{}, requests...)
+	}
+}
+
+func TestUpdateSubscriptions_WithoutSubscriptionsAndEndpoint(t *testing.T) {
+	resource, requests := newTestEndpointResource(t, http.StatusNotFound)
+	plan := endpointResourceModel{
+		EndpointName:  types.StringValue("endpoint"),
+		TopicName:     types.StringValue("bundle-1"),
+		Subscriptions: []SubscriptionModel{},
+	}
+	transaction := newEndpointTransaction(plan, endpointPrivateState{QueueExists: true, Rules: []endpointPrivateRule{}})
+
+	errs := resource.UpdateSubscriptions(context.Background(), plan, transaction)
+
+	assert.Empty(t, errs)
+	assert.Empty(t, requests(), "the rules of an endpoint, which does not exist, are not listed")
+	assert.True(t, transaction.isEmpty())
+}
+
+func TestUpdateSubscriptions_WithoutSubscriptionsOfADeletedEndpoint(t *testing.T) {
+	resource, requests := newTestEndpointResource(t, http.StatusNotFound)
+	plan := endpointResourceModel{
+		EndpointName:  types.StringValue("endpoint"),
+		TopicName:     types.StringValue("bundle-1"),
+		Subscriptions: []SubscriptionModel{},
+	}
+	transaction := newEndpointTransaction(plan, endpointPrivateState{QueueExists: true, EndpointExists: true})
+
+	errs := resource.UpdateSubscriptions(context.Background(), plan, transaction)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"GET /bundle-1/Subscriptions/endpoint/Rules/"}, requests())
+	assert.True(t, transaction.isEmpty())
+}
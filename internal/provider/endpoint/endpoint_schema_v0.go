@@ -85,7 +85,6 @@ func NewSchemaV0() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the queue should be created.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldCreateQueueIfNotExistsModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -93,7 +92,6 @@ func NewSchemaV0() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the endpoint should be created.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldCreateEndpointIfNotExistsModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -101,7 +99,6 @@ func NewSchemaV0() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the subscriptions should be updated.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldUpdateMalformedEndpointSubscriptionModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -1,9 +1,9 @@
 package endpoint
 
 import (
-	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"context"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
@@ -13,7 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func NewSchemaV1() schema.Schema {
+func NewSchemaV1(ctx context.Context) schema.Schema {
 	return schema.Schema{
 		Version: 1,
 
@@ -35,6 +35,14 @@ func NewSchemaV1() schema.Schema {
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"namespace": schema.StringAttribute{
+				Optional: true,
+				Description: "The namespace to create the endpoint in, instead of the namespace of the provider. " +
+					"Accepts the namespace name, the hostname or an sb:// url. The credential of the provider is used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"subscriptions": schema.SetNestedAttribute{
 				Required: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -104,7 +112,6 @@ func NewSchemaV1() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the queue should be created.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldCreateQueueIfNotExistsModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -112,7 +119,6 @@ func NewSchemaV1() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the endpoint should be created.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldCreateEndpointIfNotExistsModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
@@ -120,17 +126,20 @@ func NewSchemaV1() schema.Schema {
 				Computed:    true,
 				Description: "Internal attribute used to track whether the subscriptions should be updated.",
 				PlanModifiers: []planmodifier.Bool{
-					shouldUpdateMalformedEndpointSubscriptionModifier{},
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
-type endpointResourceModel struct {
+type endpointResourceModelV1 struct {
 	EndpointName              types.String                      `tfsdk:"endpoint_name"`
 	TopicName                 types.String                      `tfsdk:"topic_name"`
+	Namespace                 types.String                      `tfsdk:"namespace"`
 	Subscriptions             []SubscriptionModel               `tfsdk:"subscriptions"`
 	AdditionalQueues          []string                          `tfsdk:"additional_queues"`
 	QueueOptions              endpointResourceQueueOptionsModel `tfsdk:"queue_options"`
@@ -140,41 +149,5 @@ type endpointResourceModel struct {
 	ShouldCreateQueue         types.Bool                        `tfsdk:"should_create_queue"`
 	ShouldCreateEndpoint      types.Bool                        `tfsdk:"should_create_endpoint"`
 	ShouldUpdateSubscriptions types.Bool                        `tfsdk:"should_update_subscriptions"`
-}
-
-type SubscriptionModel struct {
-	Filter     types.String `tfsdk:"filter"`
-	FilterType types.String `tfsdk:"filter_type"`
-}
-
-func (sm *SubscriptionModel) ToAsbModel() asb.AsbSubscriptionModel {
-	return asb.AsbSubscriptionModel{
-		Filter:     sm.Filter.ValueString(),
-		FilterType: sm.FilterType.ValueString(),
-	}
-}
-
-type endpointResourceQueueOptionsModel struct {
-	EnablePartitioning        types.Bool  `tfsdk:"enable_partitioning"`
-	MaxSizeInMegabytes        types.Int64 `tfsdk:"max_size_in_megabytes"`
-	MaxMessageSizeInKilobytes types.Int64 `tfsdk:"max_message_size_in_kilobytes"`
-}
-
-func (model endpointResourceModel) ToAsbModel() asb.AsbEndpointModel {
-	subscriptions := make([]asb.AsbSubscriptionModel, len(model.Subscriptions))
-	for i, subscription := range model.Subscriptions {
-		subscriptions[i] = subscription.ToAsbModel()
-	}
-
-	return asb.AsbEndpointModel{
-		EndpointName:     model.EndpointName.ValueString(),
-		TopicName:        model.TopicName.ValueString(),
-		Subscriptions:    subscriptions,
-		AdditionalQueues: model.AdditionalQueues,
-		QueueOptions: asb.AsbEndpointQueueOptions{
-			EnablePartitioning:        model.QueueOptions.EnablePartitioning.ValueBoolPointer(),
-			MaxSizeInMegabytes:        to.Ptr(int32(model.QueueOptions.MaxSizeInMegabytes.ValueInt64())),
-			MaxMessageSizeInKilobytes: to.Ptr(model.QueueOptions.MaxMessageSizeInKilobytes.ValueInt64()),
-		},
-	}
+	Timeouts                  timeouts.Value                    `tfsdk:"timeouts"`
 }
@@ -0,0 +1,150 @@
+package endpoint
+
+import (
+	"context"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewSchemaV2(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Version: 2,
+
+		Description: "The Endpoint resource allows consumers to create and manage an NServiceBus Endpoint. " +
+			"When initially creating the Endpoint, a default deny-all rule ensures that no invalid messages are received, before the configured subscription rules have been applied.",
+
+		Attributes: map[string]schema.Attribute{
+			"endpoint_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the endpoint to create.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"topic_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the topic to create the endpoint on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Optional: true,
+				Description: "The namespace to create the endpoint in, instead of the namespace of the provider. " +
+					"Accepts the namespace name, the hostname or an sb:// url. The credential of the provider is used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subscriptions": schema.SetNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"filter": schema.StringAttribute{
+							Required:    true,
+							Description: "The filter for the subscription.",
+							Validators: []validator.String{
+								isValidCorrelationFilter(),
+							},
+						},
+						"filter_type": schema.StringAttribute{
+							Required:    true,
+							Description: "The filter type for the subscription.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("correlation", "sql"),
+							},
+						},
+					},
+				},
+			},
+			"additional_queues": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional queues to create for the endpoint.",
+			},
+			"queue_options": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The options for the queue, which is created for the endpoint.",
+				Attributes: map[string]schema.Attribute{
+					"enable_partitioning": schema.BoolAttribute{
+						Required: true,
+					},
+					"max_size_in_megabytes": schema.Int64Attribute{
+						Required: true,
+						Validators: []validator.Int64{
+							intOneOfValues([]int64{1024, 2048, 3072, 4096, 5120, 10240, 20480, 40960, 81920}),
+						},
+					},
+					"max_message_size_in_kilobytes": schema.Int64Attribute{
+						Required: true,
+					},
+				},
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional: true,
+				Description: "When a create or update fails midway, undo the changes it made in Service Bus in reverse order. " +
+					"By default, the changes are kept and recorded in the state. The next apply replaces a partially created endpoint " +
+					"and continues a partial update.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+type endpointResourceModel struct {
+	EndpointName      types.String                      `tfsdk:"endpoint_name"`
+	TopicName         types.String                      `tfsdk:"topic_name"`
+	Namespace         types.String                      `tfsdk:"namespace"`
+	Subscriptions     []SubscriptionModel               `tfsdk:"subscriptions"`
+	AdditionalQueues  []string                          `tfsdk:"additional_queues"`
+	QueueOptions      endpointResourceQueueOptionsModel `tfsdk:"queue_options"`
+	RollbackOnFailure types.Bool                        `tfsdk:"rollback_on_failure"`
+	Timeouts          timeouts.Value                    `tfsdk:"timeouts"`
+}
+
+type SubscriptionModel struct {
+	Filter     types.String `tfsdk:"filter"`
+	FilterType types.String `tfsdk:"filter_type"`
+}
+
+func (sm *SubscriptionModel) ToAsbModel() asb.AsbSubscriptionModel {
+	return asb.AsbSubscriptionModel{
+		Filter:     sm.Filter.ValueString(),
+		FilterType: sm.FilterType.ValueString(),
+	}
+}
+
+type endpointResourceQueueOptionsModel struct {
+	EnablePartitioning        types.Bool  `tfsdk:"enable_partitioning"`
+	MaxSizeInMegabytes        types.Int64 `tfsdk:"max_size_in_megabytes"`
+	MaxMessageSizeInKilobytes types.Int64 `tfsdk:"max_message_size_in_kilobytes"`
+}
+
+func (model endpointResourceModel) ToAsbModel() asb.AsbEndpointModel {
+	subscriptions := make([]asb.AsbSubscriptionModel, len(model.Subscriptions))
+	for i, subscription := range model.Subscriptions {
+		subscriptions[i] = subscription.ToAsbModel()
+	}
+
+	return asb.AsbEndpointModel{
+		EndpointName:     model.EndpointName.ValueString(),
+		TopicName:        model.TopicName.ValueString(),
+		Subscriptions:    subscriptions,
+		AdditionalQueues: model.AdditionalQueues,
+		QueueOptions: asb.AsbEndpointQueueOptions{
+			EnablePartitioning:        model.QueueOptions.EnablePartitioning.ValueBoolPointer(),
+			MaxSizeInMegabytes:        to.Ptr(int32(model.QueueOptions.MaxSizeInMegabytes.ValueInt64())),
+			MaxMessageSizeInKilobytes: to.Ptr(model.QueueOptions.MaxMessageSizeInKilobytes.ValueInt64()),
+		},
+	}
+}
@@ -7,27 +7,39 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
-func (*endpointResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+func (*endpointResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	schemaV0 := NewSchemaV0()
+	schemaV1 := NewSchemaV1(ctx)
 	return map[int64]resource.StateUpgrader{
 		0: {
-			PriorSchema:   &schemaV0,
-			StateUpgrader: udpateStateFromV0ToV1,
-		},
-	}
-}
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState endpointResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
 
-func udpateStateFromV0ToV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
-	// Convert the state from the prior schema version to the current schema version.
-	// This example demonstrates how to convert the state from the prior schema version to the current schema version.
-	// The conversion is specific to the schema changes between the prior and current versions
+				resp.Diagnostics.Append(resp.State.Set(ctx, updateStateFromV1ToV2(udpateStateFromV0ToV1(priorState)))...)
+			},
+		},
+		1: {
+			PriorSchema: &schemaV1,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState endpointResourceModelV1
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
 
-	var priorState endpointResourceModelV0
-	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
-	if resp.Diagnostics.HasError() {
-		return
+				resp.Diagnostics.Append(resp.State.Set(ctx, updateStateFromV1ToV2(priorState))...)
+			},
+		},
 	}
+}
 
+func udpateStateFromV0ToV1(priorState endpointResourceModelV0) endpointResourceModelV1 {
+	// The subscriptions of version 0 were SQL filters only
 	subscriptions := make([]SubscriptionModel, 0)
 	for _, subscription := range priorState.Subscriptions {
 		subscriptions = append(subscriptions, SubscriptionModel{
@@ -36,9 +48,10 @@ func udpateStateFromV0ToV1(ctx context.Context, req resource.UpgradeStateRequest
 		})
 	}
 
-	currrentState := endpointResourceModel{
+	return endpointResourceModelV1{
 		EndpointName:              priorState.EndpointName,
 		TopicName:                 priorState.TopicName,
+		Namespace:                 basetypes.NewStringNull(),
 		QueueOptions:              priorState.QueueOptions,
 		Subscriptions:             subscriptions,
 		AdditionalQueues:          priorState.AdditionalQueues,
@@ -48,8 +61,20 @@ func udpateStateFromV0ToV1(ctx context.Context, req resource.UpgradeStateRequest
 		ShouldCreateQueue:         priorState.ShouldCreateQueue,
 		ShouldCreateEndpoint:      priorState.ShouldCreateEndpoint,
 		ShouldUpdateSubscriptions: priorState.ShouldUpdateSubscriptions,
+		Timeouts:                  nullTimeouts(),
 	}
+}
 
-	diags := resp.State.Set(ctx, currrentState)
-	resp.Diagnostics.Append(diags...)
+// updateStateFromV1ToV2 drops the internal attributes. State upgrades cannot write the private state,
+// which is therefore filled by the next Read, see getPrivateState.
+func updateStateFromV1ToV2(priorState endpointResourceModelV1) endpointResourceModel {
+	return endpointResourceModel{
+		EndpointName:     priorState.EndpointName,
+		TopicName:        priorState.TopicName,
+		Namespace:        priorState.Namespace,
+		QueueOptions:     priorState.QueueOptions,
+		Subscriptions:    priorState.Subscriptions,
+		AdditionalQueues: priorState.AdditionalQueues,
+		Timeouts:         priorState.Timeouts,
+	}
 }
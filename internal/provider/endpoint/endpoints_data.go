@@ -0,0 +1,215 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &endpointsDataSource{}
+	_ datasource.DataSourceWithConfigure = &endpointsDataSource{}
+)
+
+func NewEndpointsDataSource() datasource.DataSource {
+	return &endpointsDataSource{}
+}
+
+type endpointsDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *endpointsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type endpointsDataSourceModel struct {
+	Namespace  types.String                       `tfsdk:"namespace"`
+	TopicName  types.String                       `tfsdk:"topic_name"`
+	NamePrefix types.String                       `tfsdk:"name_prefix"`
+	NameRegex  types.String                       `tfsdk:"name_regex"`
+	Endpoints  []endpointsDataSourceEndpointModel `tfsdk:"endpoints"`
+}
+
+type endpointsDataSourceEndpointModel struct {
+	EndpointName  types.String                          `tfsdk:"endpoint_name"`
+	ForwardTo     types.String                          `tfsdk:"forward_to"`
+	Status        types.String                          `tfsdk:"status"`
+	Subscriptions []endpointDataSourceSubscriptionModel `tfsdk:"subscriptions"`
+}
+
+func (d *endpointsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoints"
+}
+
+func (d *endpointsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The Endpoints data source provides information about all existing Endpoints on a topic.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"topic_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the topic, in which the endpoints are created",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return endpoints, whose name starts with this prefix.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return endpoints, whose name matches this regular expression.",
+			},
+			"endpoints": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The endpoints on the topic.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the endpoint.",
+						},
+						"forward_to": schema.StringAttribute{
+							Computed:    true,
+							Description: "The entity the endpoint subscription forwards messages to.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the endpoint subscription.",
+						},
+						"subscriptions": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"filter": schema.StringAttribute{
+										Computed:    true,
+										Description: "The filter for the subscription.",
+									},
+									"filter_type": schema.StringAttribute{
+										Computed:    true,
+										Description: "The filter type for the subscription.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *endpointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state endpointsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid regular expression",
+				"Could not compile name_regex: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	topicName := state.TopicName.ValueString()
+	subscriptions, err := client.GetTopicSubscriptions(ctx, topicName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Endpoints",
+			fmt.Sprintf("Could not list Endpoints of topic %s, unexpected error: %s", topicName, err.Error()),
+		)
+		return
+	}
+
+	state.Endpoints = []endpointsDataSourceEndpointModel{}
+	for _, subscription := range subscriptions {
+		endpointName := subscription.SubscriptionName
+		if !strings.HasPrefix(endpointName, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(endpointName) {
+			continue
+		}
+
+		asbSubscriptions, err := client.GetAsbSubscriptionsRules(ctx, asb.AsbEndpointModel{
+			EndpointName: endpointName,
+			TopicName:    topicName,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Subscriptions",
+				fmt.Sprintf("Could not get Subscriptions of endpoint %s, unexpected error: %s", endpointName, err.Error()),
+			)
+			return
+		}
+
+		endpointSubscriptions := make([]endpointDataSourceSubscriptionModel, 0, len(asbSubscriptions))
+		for _, asbSubscription := range asbSubscriptions {
+			endpointSubscriptions = append(endpointSubscriptions, endpointDataSourceSubscriptionModel{
+				Filter:     convertAsbFilterToEnpointFilter(asbSubscription),
+				FilterType: types.StringValue(asbSubscription.FilterType),
+			})
+		}
+
+		endpoint := endpointsDataSourceEndpointModel{
+			EndpointName:  types.StringValue(endpointName),
+			ForwardTo:     types.StringPointerValue(subscription.ForwardTo),
+			Status:        types.StringNull(),
+			Subscriptions: endpointSubscriptions,
+		}
+		if subscription.Status != nil {
+			endpoint.Status = types.StringValue(string(*subscription.Status))
+		}
+
+		state.Endpoints = append(state.Endpoints, endpoint)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,153 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &subscriptionRuleDataSource{}
+	_ datasource.DataSourceWithConfigure = &subscriptionRuleDataSource{}
+)
+
+func NewSubscriptionRuleDataSource() datasource.DataSource {
+	return &subscriptionRuleDataSource{}
+}
+
+type subscriptionRuleDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *subscriptionRuleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type subscriptionRuleDataSourceModel struct {
+	Namespace         types.String                              `tfsdk:"namespace"`
+	TopicName         types.String                              `tfsdk:"topic_name"`
+	SubscriptionName  types.String                              `tfsdk:"subscription_name"`
+	RuleName          types.String                              `tfsdk:"rule_name"`
+	MessageType       types.String                              `tfsdk:"message_type"`
+	Name              types.String                              `tfsdk:"name"`
+	FilterType        types.String                              `tfsdk:"filter_type"`
+	SqlExpression     types.String                              `tfsdk:"sql_expression"`
+	SqlParameters     map[string]string                         `tfsdk:"sql_parameters"`
+	CorrelationFilter *endpointDataSourceCorrelationFilterModel `tfsdk:"correlation_filter"`
+	Action            types.String                              `tfsdk:"action"`
+	ManagedFormat     types.Bool                                `tfsdk:"managed_format"`
+	ManagedFilter     types.String                              `tfsdk:"managed_filter"`
+}
+
+func (d *subscriptionRuleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription_rule"
+}
+
+func (d *subscriptionRuleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := ruleDetailsSchemaAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The name of the rule. When looked up by message type, this is the name this provider gives the rule, which may be cropped and suffixed with a hash.",
+	}
+	attributes["topic_name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the topic.",
+	}
+	attributes["subscription_name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the subscription. For endpoints this is the endpoint name.",
+	}
+	attributes["rule_name"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "The name of the rule. Conflicts with message_type.",
+		Validators: []validator.String{
+			stringvalidator.ExactlyOneOf(path.MatchRoot("message_type")),
+		},
+	}
+	attributes["namespace"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+	}
+	attributes["message_type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "The full name of the message type, as used in the subscriptions of the endpoint resource. Conflicts with rule_name.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "The Subscription Rule data source provides the complete filter and action of a single subscription rule, " +
+			"looked up either by its name or by the message type it was created for.",
+		Attributes: attributes,
+	}
+}
+
+func (d *subscriptionRuleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state subscriptionRuleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	ruleName := state.RuleName.ValueString()
+	if !state.MessageType.IsNull() {
+		ruleName = asb.GetSubscriptionRuleName(state.MessageType.ValueString())
+	}
+
+	rule, err := client.GetSubscriptionRuleDetails(ctx, state.TopicName.ValueString(), state.SubscriptionName.ValueString(), ruleName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Rule",
+			fmt.Sprintf("Could not get Rule %s, unexpected error: %s", ruleName, err.Error()),
+		)
+		return
+	}
+
+	if rule == nil {
+		resp.Diagnostics.AddError(
+			"Rule does not exist",
+			fmt.Sprintf("No Rule %s exist on subscription %s of topic %s", ruleName, state.SubscriptionName.ValueString(), state.TopicName.ValueString()),
+		)
+		return
+	}
+
+	ruleModel := convertAsbRuleDetailsToRuleModel(*rule)
+	state.Name = ruleModel.Name
+	state.FilterType = ruleModel.FilterType
+	state.SqlExpression = ruleModel.SqlExpression
+	state.SqlParameters = ruleModel.SqlParameters
+	state.CorrelationFilter = ruleModel.CorrelationFilter
+	state.Action = ruleModel.Action
+	state.ManagedFormat = ruleModel.ManagedFormat
+	state.ManagedFilter = ruleModel.ManagedFilter
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,69 @@
+package functions
+
+import (
+	"context"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &correlationFilterFunction{}
+
+var correlationFilterAttributeTypes = map[string]attr.Type{
+	"properties": types.MapType{ElemType: types.StringType},
+}
+
+func NewCorrelationFilterFunction() function.Function {
+	return &correlationFilterFunction{}
+}
+
+type correlationFilterFunction struct{}
+
+func (f *correlationFilterFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "correlation_filter"
+}
+
+func (f *correlationFilterFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the correlation filter the endpoint resource creates for a subscription filter.",
+		Description: "Returns the correlation filter the endpoint resource creates for a subscription filter with the filter type 'correlation'. " +
+			"The returned object has the attribute 'properties', which contains the application properties the filter matches.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "filter",
+				Description: "The filter of the subscription. Example: 'Dg.SalesOrder.V1.SalesOrderCreated'",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: correlationFilterAttributeTypes,
+		},
+	}
+}
+
+func (f *correlationFilterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filter string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &filter))
+	if resp.Error != nil {
+		return
+	}
+
+	properties, diags := types.MapValueFrom(ctx, types.StringType, asb.GetSubscriptionCorrelationFilterProperties(filter))
+	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ObjectValue(correlationFilterAttributeTypes, map[string]attr.Value{
+		"properties": properties,
+	})
+	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,53 @@
+package functions
+
+import (
+	"context"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &parseRuleExpressionFunction{}
+
+func NewParseRuleExpressionFunction() function.Function {
+	return &parseRuleExpressionFunction{}
+}
+
+type parseRuleExpressionFunction struct{}
+
+func (f *parseRuleExpressionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_rule_expression"
+}
+
+func (f *parseRuleExpressionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the subscription filter of a sql filter expression created by the endpoint resource.",
+		Description: "Returns the subscription filter of a sql filter expression created by the endpoint resource. " +
+			"Fails, if the expression does not have the format of the endpoint resource.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "The expression of the sql filter. Example: \"[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.SalesOrder.V1.SalesOrderCreated%'\"",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *parseRuleExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	filter, ok := asb.DecodeManagedSqlFilterExpression(expression)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "The expression does not have the format of the sql filters created by the endpoint resource: "+expression)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, filter))
+}
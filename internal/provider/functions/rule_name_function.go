@@ -0,0 +1,47 @@
+package functions
+
+import (
+	"context"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ruleNameFunction{}
+
+func NewRuleNameFunction() function.Function {
+	return &ruleNameFunction{}
+}
+
+type ruleNameFunction struct{}
+
+func (f *ruleNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "rule_name"
+}
+
+func (f *ruleNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the name of the rule the endpoint resource creates for a subscription filter.",
+		Description: "Returns the name of the rule the endpoint resource creates for a subscription filter. " +
+			"Filters longer than 50 characters are cropped and suffixed with a hash, so the name is unique.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "filter",
+				Description: "The filter of the subscription. Example: 'Dg.SalesOrder.V1.SalesOrderCreated'",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ruleNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filter string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &filter))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, asb.GetSubscriptionRuleName(filter)))
+}
@@ -0,0 +1,46 @@
+package functions
+
+import (
+	"context"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &sqlFilterExpressionFunction{}
+
+func NewSqlFilterExpressionFunction() function.Function {
+	return &sqlFilterExpressionFunction{}
+}
+
+type sqlFilterExpressionFunction struct{}
+
+func (f *sqlFilterExpressionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sql_filter_expression"
+}
+
+func (f *sqlFilterExpressionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Returns the expression of the sql filter the endpoint resource creates for a subscription filter.",
+		Description: "Returns the expression of the sql filter the endpoint resource creates for a subscription filter with the filter type 'sql'.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "filter",
+				Description: "The filter of the subscription. Example: 'Dg.SalesOrder.V1.SalesOrderCreated'",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *sqlFilterExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filter string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &filter))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, asb.GetSubscriptionSqlFilterExpression(filter)))
+}
@@ -0,0 +1,218 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &namespaceDataSource{}
+	_ datasource.DataSourceWithConfigure = &namespaceDataSource{}
+)
+
+func NewNamespaceDataSource() datasource.DataSource {
+	return &namespaceDataSource{}
+}
+
+type namespaceDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *namespaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type namespaceDataSourceModel struct {
+	Namespace               types.String          `tfsdk:"namespace"`
+	Name                    types.String          `tfsdk:"name"`
+	Sku                     types.String          `tfsdk:"sku"`
+	MessagingUnits          types.Int64           `tfsdk:"messaging_units"`
+	CreatedTime             types.String          `tfsdk:"created_time"`
+	ModifiedTime            types.String          `tfsdk:"modified_time"`
+	FullyQualifiedNamespace types.String          `tfsdk:"fully_qualified_namespace"`
+	QueueCount              types.Int64           `tfsdk:"queue_count"`
+	TopicCount              types.Int64           `tfsdk:"topic_count"`
+	SubscriptionCount       types.Int64           `tfsdk:"subscription_count"`
+	Quotas                  *namespaceQuotasModel `tfsdk:"quotas"`
+}
+
+type namespaceQuotasModel struct {
+	EntityCount                types.Int64   `tfsdk:"entity_count"`
+	EntityLimit                types.Int64   `tfsdk:"entity_limit"`
+	EntityUsagePercentage      types.Float64 `tfsdk:"entity_usage_percentage"`
+	MaxSubscriptionsPerTopic   types.Int64   `tfsdk:"max_subscriptions_per_topic"`
+	SubscriptionsPerTopicLimit types.Int64   `tfsdk:"subscriptions_per_topic_limit"`
+	WithinLimits               types.Bool    `tfsdk:"within_limits"`
+}
+
+func (d *namespaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_namespace"
+}
+
+func (d *namespaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The Namespace data source provides information about the Azure Service Bus namespace the provider is configured for, " +
+			"including its entity counts compared against the documented quotas of its SKU.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the namespace.",
+			},
+			"sku": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SKU of the namespace. Either 'Basic', 'Standard' or 'Premium'.",
+			},
+			"messaging_units": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of messaging units. Only set for the Premium SKU.",
+			},
+			"created_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the namespace was created, in RFC 3339 format.",
+			},
+			"modified_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the namespace was last modified, in RFC 3339 format.",
+			},
+			"fully_qualified_namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "The base address of the namespace, which is used for forwarding. Example: 'sb://my-namespace.servicebus.windows.net/'",
+			},
+			"queue_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of queues in the namespace.",
+			},
+			"topic_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of topics in the namespace.",
+			},
+			"subscription_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of subscriptions on all topics in the namespace.",
+			},
+			"quotas": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The entity counts compared against the documented quotas of the SKU.",
+				Attributes: map[string]schema.Attribute{
+					"entity_count": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of queues and topics combined.",
+					},
+					"entity_limit": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The maximum number of queues and topics combined.",
+					},
+					"entity_usage_percentage": schema.Float64Attribute{
+						Computed:    true,
+						Description: "The percentage of the entity limit, which is used.",
+					},
+					"max_subscriptions_per_topic": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The number of subscriptions on the topic with the most subscriptions.",
+					},
+					"subscriptions_per_topic_limit": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The maximum number of subscriptions per topic.",
+					},
+					"within_limits": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether all counts are below their limits.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *namespaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state namespaceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	namespace, err := client.GetNamespaceProperties(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Namespace",
+			"Could not get Namespace properties, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	counts, err := client.GetNamespaceEntityCounts(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error counting Entities",
+			"Could not count the entities of the Namespace, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	fullyQualifiedNamespace, err := client.GetFullyQualifiedNamespace(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Namespace",
+			"Could not get the address of the Namespace, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	quotas := asb.GetNamespaceQuotas(namespace)
+	entityCount := counts.QueueCount + counts.TopicCount
+
+	state.Name = types.StringValue(namespace.Name)
+	state.Sku = types.StringValue(namespace.SKU)
+	state.MessagingUnits = types.Int64PointerValue(namespace.MessagingUnits)
+	state.CreatedTime = types.StringValue(namespace.CreatedTime.Format(time.RFC3339))
+	state.ModifiedTime = types.StringValue(namespace.ModifiedTime.Format(time.RFC3339))
+	state.FullyQualifiedNamespace = types.StringValue(fullyQualifiedNamespace)
+	state.QueueCount = types.Int64Value(counts.QueueCount)
+	state.TopicCount = types.Int64Value(counts.TopicCount)
+	state.SubscriptionCount = types.Int64Value(counts.SubscriptionCount)
+	state.Quotas = &namespaceQuotasModel{
+		EntityCount:                types.Int64Value(entityCount),
+		EntityLimit:                types.Int64Value(quotas.EntityLimit),
+		EntityUsagePercentage:      types.Float64Value(float64(entityCount) * 100 / float64(quotas.EntityLimit)),
+		MaxSubscriptionsPerTopic:   types.Int64Value(counts.MaxSubscriptionsPerTopic),
+		SubscriptionsPerTopicLimit: types.Int64Value(quotas.SubscriptionsPerTopicLimit),
+		WithinLimits:               types.BoolValue(entityCount < quotas.EntityLimit && counts.MaxSubscriptionsPerTopic < quotas.SubscriptionsPerTopicLimit),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
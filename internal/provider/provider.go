@@ -2,24 +2,39 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"terraform-provider-dg-servicebus/internal/provider/auth"
 	"terraform-provider-dg-servicebus/internal/provider/endpoint"
+	"terraform-provider-dg-servicebus/internal/provider/functions"
+	"terraform-provider-dg-servicebus/internal/provider/namespace"
+	"terraform-provider-dg-servicebus/internal/provider/queue"
+	"terraform-provider-dg-servicebus/internal/provider/routing"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	azservicebus "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &DgServicebusProvider{}
+	_ provider.Provider              = &DgServicebusProvider{}
+	_ provider.ProviderWithFunctions = &DgServicebusProvider{}
 )
 
 func (p *DgServicebusProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -48,6 +63,86 @@ type DgServicebusProviderModel struct {
 	TenantId     types.String `tfsdk:"tenant_id"`
 	ClientId     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
+
+	ClientCertificatePath     types.String `tfsdk:"client_certificate_path"`
+	ClientCertificatePassword types.String `tfsdk:"client_certificate_password"`
+	UseOidc                   types.Bool   `tfsdk:"use_oidc"`
+	OidcToken                 types.String `tfsdk:"oidc_token"`
+	OidcTokenFilePath         types.String `tfsdk:"oidc_token_file_path"`
+	OidcRequestUrl            types.String `tfsdk:"oidc_request_url"`
+	OidcRequestToken          types.String `tfsdk:"oidc_request_token"`
+	UseMsi                    types.Bool   `tfsdk:"use_msi"`
+	ConnectionString          types.String `tfsdk:"connection_string"`
+	SharedAccessKeyName       types.String `tfsdk:"shared_access_key_name"`
+	SharedAccessKey           types.String `tfsdk:"shared_access_key"`
+	UseDevelopmentEmulator    types.Bool   `tfsdk:"use_development_emulator"`
+	Environment               types.String `tfsdk:"environment"`
+	EndpointSuffix            types.String `tfsdk:"endpoint_suffix"`
+	AuthorityHost             types.String `tfsdk:"authority_host"`
+
+	Preflight                   types.Bool                              `tfsdk:"preflight"`
+	MaxConcurrentRuleOperations types.Int64                             `tfsdk:"max_concurrent_rule_operations"`
+	Retry                       *DgServicebusProviderRetryModel         `tfsdk:"retry"`
+	RateLimit                   *DgServicebusProviderRateLimitModel     `tfsdk:"rate_limit"`
+	ClientOptions               *DgServicebusProviderClientOptionsModel `tfsdk:"client_options"`
+}
+
+type DgServicebusProviderRetryModel struct {
+	MaxAttempts           types.Int64 `tfsdk:"max_attempts"`
+	InitialBackoffSeconds types.Int64 `tfsdk:"initial_backoff_seconds"`
+	MaxBackoffSeconds     types.Int64 `tfsdk:"max_backoff_seconds"`
+}
+
+type DgServicebusProviderRateLimitModel struct {
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+type DgServicebusProviderClientOptionsModel struct {
+	ProxyUrl              types.String `tfsdk:"proxy_url"`
+	CaBundlePath          types.String `tfsdk:"ca_bundle_path"`
+	MinTlsVersion         types.String `tfsdk:"min_tls_version"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeoutSeconds types.Int64  `tfsdk:"request_timeout_seconds"`
+	UserAgentSuffix       types.String `tfsdk:"user_agent_suffix"`
+	ApiVersion            types.String `tfsdk:"api_version"`
+}
+
+func (m *DgServicebusProviderClientOptionsModel) ToTransportOptions(providerVersion string) auth.TransportOptions {
+	if m == nil {
+		return auth.TransportOptions{ProviderVersion: providerVersion}
+	}
+
+	return auth.TransportOptions{
+		ProxyUrl:           m.ProxyUrl.ValueString(),
+		CaBundlePath:       m.CaBundlePath.ValueString(),
+		MinTlsVersion:      m.MinTlsVersion.ValueString(),
+		InsecureSkipVerify: m.InsecureSkipVerify.ValueBool(),
+		RequestTimeout:     time.Duration(m.RequestTimeoutSeconds.ValueInt64()) * time.Second,
+		UserAgentSuffix:    m.UserAgentSuffix.ValueString(),
+		ApiVersion:         m.ApiVersion.ValueString(),
+		ProviderVersion:    providerVersion,
+	}
+}
+
+func (m *DgServicebusProviderRateLimitModel) ToAsbRateLimiter() *asb.RateLimiter {
+	if m == nil {
+		return asb.NewRateLimiter(0, 0)
+	}
+
+	return asb.NewRateLimiter(m.RequestsPerSecond.ValueFloat64(), int(m.Burst.ValueInt64()))
+}
+
+func (m *DgServicebusProviderRetryModel) ToAsbOptions() asb.RetryOptions {
+	if m == nil {
+		return asb.RetryOptions{}
+	}
+
+	return asb.RetryOptions{
+		MaxAttempts:    int(m.MaxAttempts.ValueInt64()),
+		InitialBackoff: time.Duration(m.InitialBackoffSeconds.ValueInt64()) * time.Second,
+		MaxBackoff:     time.Duration(m.MaxBackoffSeconds.ValueInt64()) * time.Second,
+	}
 }
 
 func (p *DgServicebusProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -56,9 +151,10 @@ func (p *DgServicebusProvider) Schema(_ context.Context, _ provider.SchemaReques
 
 		Attributes: map[string]schema.Attribute{
 			"azure_servicebus_hostname": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   false,
-				Description: "The hostname of the Azure Service Bus instance",
+				Optional:  true,
+				Sensitive: false,
+				Description: "The hostname of the Azure Service Bus instance. Accepts the namespace name, for example `my-namespace`, which is suffixed with the endpoint suffix of the `environment`, " +
+					"the fully qualified hostname, or an `sb://` or `https://` url. Required, unless `connection_string` is set. This can also be sourced from the `DG_SERVICEBUS_HOSTNAME` Environment Variable.",
 			},
 			"tenant_id": schema.StringAttribute{
 				Optional:    true,
@@ -66,15 +162,199 @@ func (p *DgServicebusProvider) Schema(_ context.Context, _ provider.SchemaReques
 				Description: "The Tenant ID of the service principal. This can also be sourced from the `DG_SERVICEBUS_TENANTID` Environment Variable.",
 			},
 			"client_id": schema.StringAttribute{
-				Optional:    true,
-				Sensitive:   false,
-				Description: "The Client ID of the service principal. This can also be sourced from the `DG_SERVICEBUS_CLIENTID` Environment Variable.",
+				Optional:  true,
+				Sensitive: false,
+				Description: "The Client ID of the service principal, or of the user-assigned identity when `use_msi` is enabled. " +
+					"This can also be sourced from the `DG_SERVICEBUS_CLIENTID` Environment Variable.",
 			},
 			"client_secret": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
 				Description: "The Client Secret of the service principal. This can also be sourced from the `DG_SERVICEBUS_CLIENTSECRET` Environment Variable.",
 			},
+			"client_certificate_path": schema.StringAttribute{
+				Optional: true,
+				Description: "The path to a PFX or PEM certificate, including its private key, to authenticate the service principal with. " +
+					"Takes precedence over `client_secret`. " + environmentVariablesDescription("client_certificate_path"),
+			},
+			"client_certificate_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the client certificate, if any. " + environmentVariablesDescription("client_certificate_password"),
+			},
+			"use_oidc": schema.BoolAttribute{
+				Optional: true,
+				Description: "Authenticate the service principal with a federated OIDC token, for example in GitHub Actions, Terraform Cloud or with workload identity in Kubernetes. " +
+					"Takes precedence over all other ways to authenticate. " + environmentVariablesDescription("use_oidc"),
+			},
+			"oidc_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The OIDC token, which is exchanged for an access token. " + environmentVariablesDescription("oidc_token"),
+			},
+			"oidc_token_file_path": schema.StringAttribute{
+				Optional: true,
+				Description: "The path to a file containing the OIDC token. The file is read again for every access token, such that rotated tokens are picked up. " +
+					environmentVariablesDescription("oidc_token_file_path"),
+			},
+			"oidc_request_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The url to request the OIDC token from, when neither a token nor a token file is set. " + environmentVariablesDescription("oidc_request_url"),
+			},
+			"oidc_request_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The bearer token to request the OIDC token with. " + environmentVariablesDescription("oidc_request_token"),
+			},
+			"use_msi": schema.BoolAttribute{
+				Optional: true,
+				Description: "Authenticate with a managed identity. Set `client_id` to use a user-assigned identity. " +
+					"Takes precedence over certificates and secrets. " + environmentVariablesDescription("use_msi"),
+			},
+			"connection_string": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "A connection string with a shared access key or signature, which needs the Manage claim. Takes precedence over all other ways to authenticate. " +
+					"The hostname is taken from its endpoint. " + environmentVariablesDescription("connection_string"),
+			},
+			"shared_access_key_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of a shared access policy with the Manage claim, used together with `shared_access_key`. " + environmentVariablesDescription("shared_access_key_name"),
+			},
+			"shared_access_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The key of the shared access policy. " + environmentVariablesDescription("shared_access_key"),
+			},
+			"use_development_emulator": schema.BoolAttribute{
+				Optional: true,
+				Description: "Sends the management requests over plain HTTP, as the local Service Bus emulator expects. " +
+					"Enabled automatically by `UseDevelopmentEmulator=true` in the connection string. " + environmentVariablesDescription("use_development_emulator"),
+			},
+			"environment": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("The Azure cloud of the namespace, one of %s. Defaults to `%s`. ", strings.Join(auth.GetCloudEnvironmentNames(), ", "), auth.DEFAULT_CLOUD_ENVIRONMENT) +
+					"Determines the authority host, the endpoint suffix and the token audience. " + environmentVariablesDescription("environment"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(auth.GetCloudEnvironmentNames()...),
+				},
+			},
+			"endpoint_suffix": schema.StringAttribute{
+				Optional: true,
+				Description: "Overrides the endpoint suffix of the environment, for example `servicebus.chinacloudapi.cn`. " +
+					"Tokens for a custom suffix are requested for the namespace itself. " + environmentVariablesDescription("endpoint_suffix"),
+			},
+			"authority_host": schema.StringAttribute{
+				Optional:    true,
+				Description: "Overrides the Entra ID authority host of the environment, for example `https://login.chinacloudapi.cn/`. " + environmentVariablesDescription("authority_host"),
+			},
+			"preflight": schema.BoolAttribute{
+				Optional: true,
+				Description: "Checks during configuration, that the namespace can be reached and managed with the credentials, " +
+					"such that a missing role, an unknown hostname, a wrong tenant or an expired login are reported at once with a hint how to fix them, " +
+					"instead of after all retries of the first call. Namespace overrides are checked when they are first used. " +
+					"This can also be sourced from the `DG_SERVICEBUS_PREFLIGHT` Environment Variable.",
+			},
+			"max_concurrent_rule_operations": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("The maximum number of subscription rules, which are created or deleted in parallel for an endpoint. Defaults to %d. "+
+					"Concurrent operations on a subscription can be rejected by Service Bus and are then retried. "+
+					"New rules are always created before old rules are deleted.", asb.DEFAULT_MAX_CONCURRENT_RULE_OPERATIONS),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_options": schema.SingleNestedBlock{
+				Description: "Controls the HTTP client of all requests to Service Bus and Entra ID, for example behind a corporate proxy with a private CA. " +
+					"The provider version is always added to the user agent.",
+				Attributes: map[string]schema.Attribute{
+					"proxy_url": schema.StringAttribute{
+						Optional:    true,
+						Description: "The url of the proxy for all requests, for example `http://proxy.example.com:3128`. Defaults to the `HTTPS_PROXY` and `NO_PROXY` Environment Variables.",
+					},
+					"ca_bundle_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "The path to a PEM file with CA certificates, which are trusted in addition to the ones of the system, for example of an inspecting proxy.",
+					},
+					"min_tls_version": schema.StringAttribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The minimum TLS version, one of %s. Defaults to `1.2`.", strings.Join(auth.GetTlsVersionNames(), ", ")),
+						Validators: []validator.String{
+							stringvalidator.OneOf(auth.GetTlsVersionNames()...),
+						},
+					},
+					"insecure_skip_verify": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Disables the verification of server certificates. Only meant for debugging, prefer `ca_bundle_path`.",
+					},
+					"request_timeout_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: "The timeout of every single request, including reading the response. Retries are controlled by the `retry` block. Defaults to no timeout.",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"user_agent_suffix": schema.StringAttribute{
+						Optional:    true,
+						Description: "Appended to the user agent of every request, such that the traffic can be identified, for example by Azure support.",
+					},
+					"api_version": schema.StringAttribute{
+						Optional:    true,
+						Description: "Overrides the version of the Service Bus management API, for example `2021-05`. The provider is only tested with its default version.",
+					},
+				},
+			},
+			"retry": schema.SingleNestedBlock{
+				Description: "Controls how calls to Service Bus are retried. Only transient errors, like throttling, timeouts and conflicting operations, are retried " +
+					"with an exponential backoff and jitter. When Service Bus throttles, the requested Retry-After is honoured.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The number of attempts including the first one. Defaults to %d.", asb.DEFAULT_RETRY_MAX_ATTEMPTS),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"initial_backoff_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The backoff before the first retry, which is doubled for every further retry. Defaults to %d.", int(asb.DEFAULT_RETRY_INITIAL_BACKOFF.Seconds())),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"max_backoff_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The upper bound of the backoff. Defaults to %d.", int(asb.DEFAULT_RETRY_MAX_BACKOFF.Seconds())),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+			"rate_limit": schema.SingleNestedBlock{
+				Description: "Limits the calls to the Service Bus management API across all resources and data sources of the provider, " +
+					"including those of overridden namespaces, " +
+					"such that large applies do not get the namespace throttled. When Service Bus throttles nevertheless, " +
+					"all calls are paused for the requested time and the rate is temporarily halved.",
+				Attributes: map[string]schema.Attribute{
+					"requests_per_second": schema.Float64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The sustained number of requests per second. Defaults to %d.", asb.DEFAULT_RATE_LIMIT_REQUESTS_PER_SECOND),
+						Validators: []validator.Float64{
+							float64validator.AtLeast(0.1),
+						},
+					},
+					"burst": schema.Int64Attribute{
+						Optional:    true,
+						Description: fmt.Sprintf("The number of requests, which may be sent at once after a quiet period. Defaults to %d.", asb.DEFAULT_RATE_LIMIT_BURST),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -124,14 +404,30 @@ func (p *DgServicebusProvider) Configure(ctx context.Context, req provider.Confi
 		)
 	}
 
+	if config.Preflight.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preflight"),
+			"Unknown Preflight",
+			"The provider cannot determine whether to check the access to the namespace, as there is an unknown configuration value for preflight. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the DG_SERVICEBUS_PREFLIGHT environment variable.",
+		)
+	}
+
+	config.addUnknownAuthAttributeErrors(&resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	hostname := os.Getenv("DG_SERVICEBUS_HOSTNAME")
 	tenantId := os.Getenv("DG_SERVICEBUS_TENANTID")
 	clientId := os.Getenv("DG_SERVICEBUS_CLIENTID")
 	clientSecret := os.Getenv("DG_SERVICEBUS_CLIENTSECRET")
 
+	if !config.Hostname.IsNull() {
+		hostname = config.Hostname.ValueString()
+	}
+
 	if !config.TenantId.IsNull() {
 		tenantId = config.TenantId.ValueString()
 	}
@@ -144,43 +440,162 @@ func (p *DgServicebusProvider) Configure(ctx context.Context, req provider.Confi
 		clientSecret = config.ClientSecret.ValueString()
 	}
 
+	preflight := false
+	if !config.Preflight.IsNull() {
+		preflight = config.Preflight.ValueBool()
+	} else if preflightValue := os.Getenv("DG_SERVICEBUS_PREFLIGHT"); preflightValue != "" {
+		var err error
+		preflight, err = strconv.ParseBool(preflightValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("preflight"),
+				"Invalid preflight",
+				fmt.Sprintf("The value %q of the DG_SERVICEBUS_PREFLIGHT environment variable is not a boolean.", preflightValue),
+			)
+		}
+	}
+
+	authConfig := config.toAuthConfig(tenantId, clientId, clientSecret, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, "dgservicebus_client_secret", clientSecret)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "dgservicebus_client_secret")
+	ctx = tflog.SetField(ctx, "dgservicebus_client_certificate_password", authConfig.ClientCertificatePassword)
+	ctx = tflog.SetField(ctx, "dgservicebus_oidc_token", authConfig.OidcToken)
+	ctx = tflog.SetField(ctx, "dgservicebus_oidc_request_token", authConfig.OidcRequestToken)
+	ctx = tflog.SetField(ctx, "dgservicebus_connection_string", authConfig.ConnectionString)
+	ctx = tflog.SetField(ctx, "dgservicebus_shared_access_key", authConfig.SharedAccessKey)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx,
+		"dgservicebus_client_secret",
+		"dgservicebus_client_certificate_password",
+		"dgservicebus_oidc_token",
+		"dgservicebus_oidc_request_token",
+		"dgservicebus_connection_string",
+		"dgservicebus_shared_access_key",
+	)
+
+	hostname, err := authConfig.GetHostname(hostname)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("azure_servicebus_hostname"),
+			"Invalid Azure Service Bus Hostname",
+			"The provider cannot determine which Azure Service Bus instance to connect to: "+err.Error(),
+		)
+		return
+	}
 
-	tflog.Debug(ctx, "Creating Azure Authentication Credential")
+	if err := authConfig.Cloud.CheckHostname(hostname); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("environment"),
+			"Hostname of another Azure cloud",
+			"Authentication and forwarding will likely fail: "+err.Error(),
+		)
+	}
+
+	tflog.Debug(ctx, "Creating Azure Service Bus client")
 
-	var credential azcore.TokenCredential
-	var err error
+	clientOptions, err := config.ClientOptions.ToTransportOptions(p.version).NewClientOptions()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_options"),
+			"Invalid Client Options",
+			"The provider cannot create the HTTP client: "+err.Error(),
+		)
+		return
+	}
 
-	if tenantId != "" && clientId != "" && clientSecret != "" {
-		credential, err = azidentity.NewClientSecretCredential(tenantId, clientId, clientSecret, nil)
-	} else {
-		credential, err = azidentity.NewDefaultAzureCredential(nil)
+	if config.ClientOptions != nil && config.ClientOptions.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("client_options").AtName("insecure_skip_verify"),
+			"Server Certificates Are Not Verified",
+			"The certificates of Service Bus and Entra ID are not verified, which exposes the credentials to anyone in the network path. "+
+				"Use 'ca_bundle_path' to trust the CA of a proxy instead.",
+		)
 	}
 
+	// Retries are done by the client wrapper, so every call has the same policy
+	clientOptions.Retry = policy.RetryOptions{MaxRetries: -1}
+	clientFactory, err := auth.NewAdminClientFactory(authConfig, azservicebus.ClientOptions{
+		ClientOptions: clientOptions,
+	})
+	var adminClient *azservicebus.Client
+	if err == nil {
+		adminClient, err = clientFactory.NewAdminClient(hostname)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Azure Client",
-			"Authentication failed. Either provide the necessary information to authenticate with a service principal "+
-				"('tenant_id', 'client_id' and 'client_secret') or ensure there is a token source configured for the default credential available. "+
-				"See a list token sources here: https://learn.microsoft.com/en-us/python/api/azure-identity/azure.identity.defaultazurecredential?view=azure-python'\n"+
+			"Authentication failed. In order of precedence, the provider authenticates with "+
+				"a connection string ('connection_string'), "+
+				"a shared access key ('shared_access_key_name' and 'shared_access_key'), "+
+				"a federated OIDC token ('use_oidc' with 'tenant_id' and 'client_id'), "+
+				"a managed identity ('use_msi', optionally with the 'client_id' of a user-assigned identity), "+
+				"a client certificate ('client_certificate_path' with 'tenant_id' and 'client_id'), "+
+				"a client secret ('client_secret' with 'tenant_id' and 'client_id'), "+
+				"or the default credential, when none of these are set. "+
+				"See a list of token sources of the default credential here: https://learn.microsoft.com/en-us/azure/developer/go/azure-sdk-authentication\n"+
 				"Azure Client Error: "+err.Error(),
 		)
 		return
 	}
 
-	client, err := azservicebus.NewClient(config.Hostname.ValueString(), credential, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"An error occurred while configuring the provider",
-			"Could not create Azure Service Bus client: "+err.Error(),
-		)
+	tflog.Info(ctx, "Authenticating with Azure", map[string]any{
+		"method":      string(clientFactory.Method),
+		"hostname":    hostname,
+		"environment": authConfig.Cloud.Name,
+	})
+
+	// The rate limit applies to the provider, thus the clients of all namespaces share the limiter.
+	// The cache is per namespace, as it holds its entities.
+	rateLimiter := config.RateLimit.ToAsbRateLimiter()
+	newClient := func(adminClient *azservicebus.Client, hostname string) *asb.AsbClientWrapper {
+		return &asb.AsbClientWrapper{
+			Client:                      adminClient,
+			Hostname:                    hostname,
+			MaxConcurrentRuleOperations: int(config.MaxConcurrentRuleOperations.ValueInt64()),
+			Cache:                       asb.NewNamespaceCache(),
+			Retry:                       config.Retry.ToAsbOptions(),
+			RateLimiter:                 rateLimiter,
+		}
+	}
 
-		return
+	client := newClient(adminClient, hostname)
+	client.Namespaces = asb.NewNamespaceClients(
+		client,
+		func(namespace string) (string, error) {
+			return auth.NormalizeHostname(namespace, authConfig.Cloud.EndpointSuffix)
+		},
+		func(hostname string) (*asb.AsbClientWrapper, error) {
+			tflog.Info(ctx, "Creating Azure Service Bus client for namespace override", map[string]any{"hostname": hostname})
+
+			adminClient, err := clientFactory.NewAdminClient(hostname)
+			if err != nil {
+				return nil, err
+			}
+
+			client := newClient(adminClient, hostname)
+			if preflight {
+				// The clients for namespace overrides are created after Configure has returned
+				if err := client.Preflight(context.WithoutCancel(ctx)); err != nil {
+					summary, detail := clientFactory.DiagnoseError(err, hostname)
+					return nil, fmt.Errorf("%s: %s %w", strings.ToLower(summary), detail, err)
+				}
+			}
+
+			return client, nil
+		},
+	)
+
+	if preflight {
+		tflog.Debug(ctx, "Checking the access to the Azure Service Bus namespace", map[string]any{"hostname": hostname})
+
+		if err := client.Preflight(ctx); err != nil {
+			summary, detail := clientFactory.DiagnoseError(err, hostname)
+			resp.Diagnostics.AddError(summary, detail+"\n\nAzure Service Bus Error: "+err.Error())
+			return
+		}
 	}
 
 	resp.DataSourceData = client
@@ -192,6 +607,13 @@ func (p *DgServicebusProvider) Configure(ctx context.Context, req provider.Confi
 func (p *DgServicebusProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		endpoint.NewEndpointDataSource,
+		endpoint.NewEndpointsDataSource,
+		endpoint.NewEndpointHealthDataSource,
+		endpoint.NewSubscriptionRuleDataSource,
+		routing.NewRoutingSimulationDataSource,
+		routing.NewMessageTypeSubscribersDataSource,
+		namespace.NewNamespaceDataSource,
+		queue.NewQueuesDataSource,
 	}
 }
 
@@ -200,3 +622,12 @@ func (p *DgServicebusProvider) Resources(_ context.Context) []func() resource.Re
 		endpoint.NewEndpointResource,
 	}
 }
+
+func (p *DgServicebusProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewRuleNameFunction,
+		functions.NewSqlFilterExpressionFunction,
+		functions.NewCorrelationFilterFunction,
+		functions.NewParseRuleExpressionFunction,
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"terraform-provider-dg-servicebus/internal/provider/auth"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The attributes for authentication and the cloud, which were added with the credential options.
+// The attributes for the client secret have their own diagnostics in Configure.
+type authAttribute struct {
+	name             string
+	environmentNames []string // The first one set is used, when the attribute is null
+}
+
+var authAttributes = []authAttribute{
+	{"client_certificate_path", []string{"DG_SERVICEBUS_CLIENT_CERTIFICATE_PATH"}},
+	{"client_certificate_password", []string{"DG_SERVICEBUS_CLIENT_CERTIFICATE_PASSWORD"}},
+	{"use_oidc", []string{"DG_SERVICEBUS_USE_OIDC"}},
+	{"oidc_token", []string{"DG_SERVICEBUS_OIDC_TOKEN", "TFC_WORKLOAD_IDENTITY_TOKEN"}},
+	{"oidc_token_file_path", []string{"DG_SERVICEBUS_OIDC_TOKEN_FILE_PATH", "AZURE_FEDERATED_TOKEN_FILE"}},
+	{"oidc_request_url", []string{"DG_SERVICEBUS_OIDC_REQUEST_URL", "ACTIONS_ID_TOKEN_REQUEST_URL"}},
+	{"oidc_request_token", []string{"DG_SERVICEBUS_OIDC_REQUEST_TOKEN", "ACTIONS_ID_TOKEN_REQUEST_TOKEN"}},
+	{"use_msi", []string{"DG_SERVICEBUS_USE_MSI"}},
+	{"connection_string", []string{"DG_SERVICEBUS_CONNECTION_STRING"}},
+	{"shared_access_key_name", []string{"DG_SERVICEBUS_SHARED_ACCESS_KEY_NAME"}},
+	{"shared_access_key", []string{"DG_SERVICEBUS_SHARED_ACCESS_KEY"}},
+	{"use_development_emulator", []string{"DG_SERVICEBUS_USE_DEVELOPMENT_EMULATOR"}},
+	{"environment", []string{"DG_SERVICEBUS_ENVIRONMENT"}},
+	{"endpoint_suffix", []string{"DG_SERVICEBUS_ENDPOINT_SUFFIX"}},
+	{"authority_host", []string{"DG_SERVICEBUS_AUTHORITY_HOST"}},
+}
+
+func (config DgServicebusProviderModel) authAttributeValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"client_certificate_path":     config.ClientCertificatePath,
+		"client_certificate_password": config.ClientCertificatePassword,
+		"use_oidc":                    config.UseOidc,
+		"oidc_token":                  config.OidcToken,
+		"oidc_token_file_path":        config.OidcTokenFilePath,
+		"oidc_request_url":            config.OidcRequestUrl,
+		"oidc_request_token":          config.OidcRequestToken,
+		"use_msi":                     config.UseMsi,
+		"connection_string":           config.ConnectionString,
+		"shared_access_key_name":      config.SharedAccessKeyName,
+		"shared_access_key":           config.SharedAccessKey,
+		"use_development_emulator":    config.UseDevelopmentEmulator,
+		"environment":                 config.Environment,
+		"endpoint_suffix":             config.EndpointSuffix,
+		"authority_host":              config.AuthorityHost,
+	}
+}
+
+func environmentVariablesDescription(attribute string) string {
+	for _, authAttribute := range authAttributes {
+		if authAttribute.name != attribute {
+			continue
+		}
+
+		description := fmt.Sprintf("This can also be sourced from the `%s` Environment Variable", authAttribute.environmentNames[0])
+		for _, fallback := range authAttribute.environmentNames[1:] {
+			description += fmt.Sprintf(", falling back to `%s`", fallback)
+		}
+
+		return description + "."
+	}
+
+	return ""
+}
+
+// addUnknownAuthAttributeErrors adds an error for every authentication attribute, which is unknown during configuration.
+func (config DgServicebusProviderModel) addUnknownAuthAttributeErrors(diagnostics *diag.Diagnostics) {
+	values := config.authAttributeValues()
+	for _, attribute := range authAttributes {
+		if !values[attribute.name].IsUnknown() {
+			continue
+		}
+
+		diagnostics.AddAttributeError(
+			path.Root(attribute.name),
+			"Unknown "+attribute.name,
+			fmt.Sprintf("The provider cannot determine which authentication configuration to use, as there is an unknown configuration value for %s. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the %s environment variable.",
+				attribute.name, attribute.environmentNames[0]),
+		)
+	}
+}
+
+// toAuthConfig resolves the authentication settings, where configured values take precedence over environment variables.
+func (config DgServicebusProviderModel) toAuthConfig(tenantId string, clientId string, clientSecret string, diagnostics *diag.Diagnostics) auth.AuthConfig {
+	values := config.authAttributeValues()
+	resolved := map[string]string{}
+	for _, attribute := range authAttributes {
+		switch value := values[attribute.name].(type) {
+		case types.String:
+			if !value.IsNull() {
+				resolved[attribute.name] = value.ValueString()
+				continue
+			}
+		case types.Bool:
+			if !value.IsNull() {
+				resolved[attribute.name] = strconv.FormatBool(value.ValueBool())
+				continue
+			}
+		}
+
+		for _, environmentName := range attribute.environmentNames {
+			if environmentValue := os.Getenv(environmentName); environmentValue != "" {
+				resolved[attribute.name] = environmentValue
+				break
+			}
+		}
+	}
+
+	parseBool := func(attribute string) bool {
+		if resolved[attribute] == "" {
+			return false
+		}
+
+		value, err := strconv.ParseBool(resolved[attribute])
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid "+attribute,
+				fmt.Sprintf("The value %q of %s is not a boolean.", resolved[attribute], attribute),
+			)
+		}
+
+		return value
+	}
+
+	cloudEnvironment, err := auth.GetCloudEnvironment(resolved["environment"], resolved["endpoint_suffix"], resolved["authority_host"])
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Invalid cloud environment",
+			"The provider cannot determine the endpoints of the Azure cloud: "+err.Error(),
+		)
+	}
+
+	return auth.AuthConfig{
+		TenantId:                  tenantId,
+		ClientId:                  clientId,
+		ClientSecret:              clientSecret,
+		ClientCertificatePath:     resolved["client_certificate_path"],
+		ClientCertificatePassword: resolved["client_certificate_password"],
+		UseOidc:                   parseBool("use_oidc"),
+		OidcToken:                 resolved["oidc_token"],
+		OidcTokenFilePath:         resolved["oidc_token_file_path"],
+		OidcRequestUrl:            resolved["oidc_request_url"],
+		OidcRequestToken:          resolved["oidc_request_token"],
+		UseMsi:                    parseBool("use_msi"),
+		ConnectionString:          resolved["connection_string"],
+		SharedAccessKeyName:       resolved["shared_access_key_name"],
+		SharedAccessKey:           resolved["shared_access_key"],
+		UseDevelopmentEmulator:    parseBool("use_development_emulator"),
+		Cloud:                     cloudEnvironment,
+	}
+}
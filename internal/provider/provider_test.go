@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const providerConfig = `
@@ -49,11 +50,13 @@ func createClient(t *testing.T) asb.AsbClientWrapper {
 	}
 	assert.Nil(t, err, "No error expected")
 
-	admin_client, err := azservicebus.NewClient("DG-PROD-Chabis-Messaging-Testing.servicebus.windows.net", credential, nil)
+	hostname := "DG-PROD-Chabis-Messaging-Testing.servicebus.windows.net"
+	admin_client, err := azservicebus.NewClient(hostname, credential, nil)
 	assert.Nil(t, err, "No error expected")
 
 	return asb.AsbClientWrapper{
-		Client: admin_client,
+		Client:   admin_client,
+		Hostname: hostname,
 	}
 }
 
@@ -88,15 +91,15 @@ func TestAcc_TestCreateEndpoint(t *testing.T) {
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "subscriptions.#", "1"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "subscriptions.0.filter", filterValue),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "subscriptions.0.filter_type", filterType),
-				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "endpoint_exists", "true"),
+				resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "endpoint_exists"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "endpoint_name", endpoint_name),
-				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "queue_exists", "true"),
+				resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "queue_exists"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "queue_options.enable_partitioning", "true"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "queue_options.max_size_in_megabytes", "5120"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "queue_options.max_message_size_in_kilobytes", "256"),
-				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "should_create_endpoint", "false"),
-				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "should_create_queue", "false"),
-				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "should_update_subscriptions", "false"),
+				resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "should_create_endpoint"),
+				resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "should_create_queue"),
+				resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "should_update_subscriptions"),
 				resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "topic_name", "bundle-1"),
 			),
 		})
@@ -675,6 +678,500 @@ func TestAcc_EndpointStateUpgrader(t *testing.T) {
 	ensure_enpoint_deleted(createClient(t), endpoint_name)
 }
 
+func TestAcc_RoutingSimulationDataSource(t *testing.T) {
+	client := createClient(t)
+	ctx := context.Background()
+
+	endpoint_name, _ := create_test_endpoint(client, 0, "sql", true)
+	messageType := fmt.Sprintf("Dg.Test.Routing%v.V1", acctest.RandString(10))
+	model := asb.AsbEndpointModel{
+		EndpointName: endpoint_name,
+		TopicName:    "bundle-1",
+	}
+	err := client.CreateAsbSubscriptionRule(ctx, model, asb.AsbSubscriptionModel{Filter: messageType, FilterType: "sql"})
+	assert.Nil(t, err, "Could not create rule "+messageType)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_routing_simulation" "test" {
+					topic_name         = "bundle-1"
+					message_properties = {
+						"NServiceBus.EnclosedMessageTypes" = "%v, Dg.Test, Version=1.0.0.0"
+					}
+				}
+				`, messageType),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_routing_simulation.test", "receivers.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_routing_simulation.test", "receivers.0.endpoint_name", endpoint_name),
+					resource.TestCheckResourceAttr("data.dgservicebus_routing_simulation.test", "receivers.0.matched_rules.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_routing_simulation.test", "receivers.0.matched_rules.0.rule_name", messageType),
+					resource.TestCheckResourceAttr("data.dgservicebus_routing_simulation.test", "receivers.0.matched_rules.0.filter_type", "sql"),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_MessageTypeSubscribersDataSource(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, subscriptions := create_test_endpoint(client, 1, "correlation", true)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_message_type_subscribers" "test" {
+					message_type = "%v"
+					topic_names  = ["bundle-1"]
+				}
+				`, subscriptions[0].Filter),
+				Check: func(s *terraform.State) error {
+					attributes := s.RootModule().Resources["data.dgservicebus_message_type_subscribers.test"].Primary.Attributes
+					for key, value := range attributes {
+						if strings.HasSuffix(key, ".endpoint_name") && value == endpoint_name {
+							prefix := strings.TrimSuffix(key, "endpoint_name")
+							if attributes[prefix+"filter_type"] != "correlation" || attributes[prefix+"rule_name"] != subscriptions[0].Filter {
+								return fmt.Errorf("Expected correlation rule %v for endpoint %v", subscriptions[0].Filter, endpoint_name)
+							}
+							return nil
+						}
+					}
+					return fmt.Errorf("Expected endpoint %v to be a subscriber of %v", endpoint_name, subscriptions[0].Filter)
+				},
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_EndpointsDataSource(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, subscriptions := create_test_endpoint(client, 1, "sql", true)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_endpoints" "test" {
+					topic_name  = "bundle-1"
+					name_prefix = "%v"
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.endpoint_name", endpoint_name),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.status", "Active"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_endpoints.test", "endpoints.0.forward_to"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.subscriptions.0.filter", subscriptions[0].Filter),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.subscriptions.0.filter_type", "sql"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_endpoints" "test" {
+					topic_name = "bundle-1"
+					name_regex = "^%v$"
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoints.test", "endpoints.0.endpoint_name", endpoint_name),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_NamespaceDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+				data "dgservicebus_namespace" "test" {
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_namespace.test", "name", "DG-PROD-Chabis-Messaging-Testing"),
+					resource.TestCheckResourceAttr("data.dgservicebus_namespace.test", "fully_qualified_namespace", "sb://DG-PROD-Chabis-Messaging-Testing.servicebus.windows.net/"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_namespace.test", "sku"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_namespace.test", "queue_count"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_namespace.test", "topic_count"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_namespace.test", "subscription_count"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_namespace.test", "quotas.entity_limit"),
+					resource.TestCheckResourceAttr("data.dgservicebus_namespace.test", "quotas.subscriptions_per_topic_limit", "2000"),
+				),
+			},
+		},
+	})
+}
+
+func TestAcc_EndpointHealthDataSource(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, _ := create_test_endpoint(client, 1, "sql", true)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_endpoint_health" "test" {
+					endpoint_name = "%v"
+					topic_name    = "bundle-1"
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoint_health.test", "queue.active_message_count", "0"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoint_health.test", "queue.dead_letter_message_count", "0"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_endpoint_health.test", "queue.size_in_bytes"),
+					resource.TestCheckResourceAttrSet("data.dgservicebus_endpoint_health.test", "queue.accessed_at"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoint_health.test", "subscription.dead_letter_message_count", "0"),
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoint_health.test", "subscription.transfer_dead_letter_message_count", "0"),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_EndpointDataSourceForeignRules(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, subscriptions := create_test_endpoint(client, 1, "sql", true)
+
+	// A rule not created by this provider, like the ones created by the NServiceBus installer
+	foreignExpression := "[NServiceBus.EnclosedMessageTypes] LIKE '%Dg.Test.Foreign%' AND Priority > @priority"
+	_, err := client.Client.CreateRule(context.Background(), "bundle-1", endpoint_name, &azservicebus.CreateRuleOptions{
+		Name: pointer.String("foreign-rule"),
+		Filter: &azservicebus.SQLFilter{
+			Expression: foreignExpression,
+			Parameters: map[string]any{"@priority": int64(3)},
+		},
+		Action: &azservicebus.SQLAction{Expression: "SET Routed = TRUE"},
+	})
+	assert.Nil(t, err, "No error expected")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_endpoint" "test" {
+					endpoint_name = "%v"
+					topic_name    = "bundle-1"
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_endpoint.test", "status", "Active"),
+					resource.TestCheckTypeSetElemAttr("data.dgservicebus_endpoint.test", "subscriptions.*.filter", subscriptions[0].Filter),
+					resource.TestCheckTypeSetElemAttr("data.dgservicebus_endpoint.test", "subscriptions.*.filter", foreignExpression),
+					resource.TestCheckTypeSetElemNestedAttrs("data.dgservicebus_endpoint.test", "rules.*", map[string]string{
+						"filter_type":    "sql",
+						"managed_format": "true",
+						"managed_filter": subscriptions[0].Filter,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.dgservicebus_endpoint.test", "rules.*", map[string]string{
+						"name":                     "foreign-rule",
+						"filter_type":              "sql",
+						"sql_expression":           foreignExpression,
+						"sql_parameters.@priority": "3",
+						"action":                   "SET Routed = TRUE",
+						"managed_format":           "false",
+					}),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_SubscriptionRuleDataSource(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, _ := create_test_endpoint(client, 0, "sql", true)
+
+	// Long enough to be cropped and suffixed with a hash
+	messageType := "Dg.Test.Subscription.V1.AVeryLongMessageTypeNameWhichIsCroppedInTheRuleName"
+	err := client.CreateAsbSubscriptionRule(context.Background(), asb.AsbEndpointModel{
+		EndpointName: endpoint_name,
+		TopicName:    "bundle-1",
+	}, asb.AsbSubscriptionModel{
+		Filter:     messageType,
+		FilterType: "correlation",
+	})
+	assert.Nil(t, err, "No error expected")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_subscription_rule" "test" {
+					topic_name        = "bundle-1"
+					subscription_name = "%v"
+					message_type      = "%v"
+				}
+				`, endpoint_name, messageType),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "name", asb.GetSubscriptionRuleName(messageType)),
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "filter_type", "correlation"),
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "correlation_filter.application_properties.Dg.MessageTypeFullName", messageType),
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "managed_format", "true"),
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "managed_filter", messageType),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_subscription_rule" "test" {
+					topic_name        = "bundle-1"
+					subscription_name = "%v"
+					rule_name         = "%v"
+				}
+				`, endpoint_name, asb.GetSubscriptionRuleName(messageType)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_subscription_rule.test", "managed_filter", messageType),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_QueuesDataSource(t *testing.T) {
+	client := createClient(t)
+
+	endpoint_name, _ := create_test_endpoint(client, 0, "sql", true)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_queues" "test" {
+					name_prefix = "%v"
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.0.name", endpoint_name),
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.0.enable_partitioning", "true"),
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.0.max_message_size_in_kilobytes", "256"),
+					resource.TestCheckNoResourceAttr("data.dgservicebus_queues.test", "queues.0.runtime_properties.active_message_count"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				data "dgservicebus_queues" "test" {
+					name_regex                 = "^%v$"
+					include_runtime_properties = true
+				}
+				`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.#", "1"),
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.0.runtime_properties.active_message_count", "0"),
+					resource.TestCheckResourceAttr("data.dgservicebus_queues.test", "queues.0.runtime_properties.dead_letter_message_count", "0"),
+				),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(client, endpoint_name)
+}
+
+func TestAcc_Functions(t *testing.T) {
+	shortFilter := "Dg.SalesOrder.V1.SalesOrderCreated"
+	longFilter := "Dg.SalesOrder.V1.AVeryLongMessageTypeNameWhichIsCroppedInTheRuleName"
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				output "short_rule_name" {
+					value = provider::dgservicebus::rule_name("%[1]v")
+				}
+				output "long_rule_name" {
+					value = provider::dgservicebus::rule_name("%[2]v")
+				}
+				output "sql_filter_expression" {
+					value = provider::dgservicebus::sql_filter_expression("%[1]v")
+				}
+				output "correlation_filter_property" {
+					value = provider::dgservicebus::correlation_filter("%[1]v").properties["Dg.MessageTypeFullName"]
+				}
+				output "parsed_rule_expression" {
+					value = provider::dgservicebus::parse_rule_expression(provider::dgservicebus::sql_filter_expression("%[1]v"))
+				}
+				`, shortFilter, longFilter),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("short_rule_name", shortFilter),
+					resource.TestCheckOutput("long_rule_name", "ypeNameWhichIsCroppedInTheRuleName--KsxwXG3zTCOI7w"),
+					resource.TestCheckOutput("sql_filter_expression", "[NServiceBus.EnclosedMessageTypes] LIKE '%"+shortFilter+"%'"),
+					resource.TestCheckOutput("correlation_filter_property", shortFilter),
+					resource.TestCheckOutput("parsed_rule_expression", shortFilter),
+				),
+			},
+			{
+				Config: `
+				output "parsed_rule_expression" {
+					value = provider::dgservicebus::parse_rule_expression("Priority > 3")
+				}
+				`,
+				ExpectError: regexp.MustCompile("does not have the format"),
+			},
+		},
+	})
+}
+
+func TestAcc_EndpointManySubscriptions(t *testing.T) {
+	endpoint_name := acctest.RandString(10) + "-test-many-subscriptions"
+	concurrentProviderConfig := `
+provider "dgservicebus" {
+    azure_servicebus_hostname      = "DG-PROD-Chabis-Messaging-Testing.servicebus.windows.net"
+    tenant_id                      = "35aa8c5b-ac0a-4b15-9788-ff6dfa22901f"
+    max_concurrent_rule_operations = 5
+}
+`
+
+	subscriptionsConfig := func(version int) string {
+		subscriptions := []string{}
+		for i := 0; i < 30; i++ {
+			subscriptions = append(subscriptions, fmt.Sprintf(`{filter = "Dg.Test.V%v.Subscription%v", filter_type = "sql"}`, version, i))
+		}
+		return strings.Join(subscriptions, ",\n")
+	}
+
+	endpointConfig := func(version int) string {
+		return concurrentProviderConfig + fmt.Sprintf(`
+		resource "dgservicebus_endpoint" "test" {
+			endpoint_name = "%v"
+			topic_name    = "bundle-1"
+			subscriptions = [
+				%v
+			]
+
+			queue_options = {
+				enable_partitioning           = true,
+				max_size_in_megabytes         = 5120,
+				max_message_size_in_kilobytes = 256
+			}
+		}`, endpoint_name, subscriptionsConfig(version))
+	}
+
+	// The subscriptions are a set, thus every filter is checked by its value
+	subscriptionsCheck := func(version int) resource.TestCheckFunc {
+		checks := []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "subscriptions.#", "30"),
+			resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "should_update_subscriptions"),
+		}
+		for i := 0; i < 30; i++ {
+			checks = append(checks, resource.TestCheckTypeSetElemNestedAttrs("dgservicebus_endpoint.test", "subscriptions.*", map[string]string{
+				"filter":      fmt.Sprintf("Dg.Test.V%v.Subscription%v", version, i),
+				"filter_type": "sql",
+			}))
+		}
+		return resource.ComposeAggregateTestCheckFunc(checks...)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: endpointConfig(1),
+				Check:  subscriptionsCheck(1),
+			},
+			// Replace every subscription
+			{
+				Config: endpointConfig(2),
+				Check:  subscriptionsCheck(2),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(createClient(t), endpoint_name)
+}
+
+func TestAcc_EndpointTimeouts(t *testing.T) {
+	endpoint_name := acctest.RandString(10) + "-test-timeouts"
+	timed_out_endpoint_name := acctest.RandString(10) + "-test-timeouts"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "dgservicebus_endpoint" "test" {
+					endpoint_name = "%v"
+					topic_name    = "bundle-1"
+					subscriptions = [
+						{filter = "Dg.Test.V1.Subscription", filter_type = "sql"}
+					]
+
+					queue_options = {
+						enable_partitioning           = true,
+						max_size_in_megabytes         = 5120,
+						max_message_size_in_kilobytes = 256
+					}
+
+					timeouts {
+						create = "15m"
+						delete = "10m"
+					}
+				}`, endpoint_name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "timeouts.create", "15m"),
+					resource.TestCheckResourceAttr("dgservicebus_endpoint.test", "timeouts.delete", "10m"),
+					resource.TestCheckNoResourceAttr("dgservicebus_endpoint.test", "timeouts.update"),
+				),
+			},
+			{
+				Config: providerConfig + fmt.Sprintf(`
+				resource "dgservicebus_endpoint" "test" {
+					endpoint_name = "%v"
+					topic_name    = "bundle-1"
+					subscriptions = [
+						{filter = "Dg.Test.V1.Subscription", filter_type = "sql"}
+					]
+
+					queue_options = {
+						enable_partitioning           = true,
+						max_size_in_megabytes         = 5120,
+						max_message_size_in_kilobytes = 256
+					}
+
+					timeouts {
+						create = "1s"
+					}
+				}`, timed_out_endpoint_name),
+				ExpectError: regexp.MustCompile("Timeout during create of endpoint"),
+			},
+		},
+	})
+
+	ensure_enpoint_deleted(createClient(t), endpoint_name)
+	ensure_enpoint_deleted(createClient(t), timed_out_endpoint_name)
+}
+
 // Helper functions.
 func create_test_endpoint(client asb.AsbClientWrapper, number_of_subscription uint, subscription_filter_type string, create_queue bool) (endpoint_name string, subscriptions []asb.AsbSubscriptionModel) {
 	uuid := acctest.RandString(10)
@@ -0,0 +1,305 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+	"time"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &queuesDataSource{}
+	_ datasource.DataSourceWithConfigure = &queuesDataSource{}
+)
+
+func NewQueuesDataSource() datasource.DataSource {
+	return &queuesDataSource{}
+}
+
+type queuesDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *queuesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type queuesDataSourceModel struct {
+	Namespace                types.String `tfsdk:"namespace"`
+	NamePrefix               types.String `tfsdk:"name_prefix"`
+	NameRegex                types.String `tfsdk:"name_regex"`
+	IncludeRuntimeProperties types.Bool   `tfsdk:"include_runtime_properties"`
+	Queues                   []queueModel `tfsdk:"queues"`
+}
+
+type queueModel struct {
+	Name                             types.String                 `tfsdk:"name"`
+	Status                           types.String                 `tfsdk:"status"`
+	EnablePartitioning               types.Bool                   `tfsdk:"enable_partitioning"`
+	MaxSizeInMegabytes               types.Int64                  `tfsdk:"max_size_in_megabytes"`
+	MaxMessageSizeInKilobytes        types.Int64                  `tfsdk:"max_message_size_in_kilobytes"`
+	MaxDeliveryCount                 types.Int64                  `tfsdk:"max_delivery_count"`
+	LockDuration                     types.String                 `tfsdk:"lock_duration"`
+	DefaultMessageTimeToLive         types.String                 `tfsdk:"default_message_time_to_live"`
+	RequiresSession                  types.Bool                   `tfsdk:"requires_session"`
+	DeadLetteringOnMessageExpiration types.Bool                   `tfsdk:"dead_lettering_on_message_expiration"`
+	ForwardTo                        types.String                 `tfsdk:"forward_to"`
+	ForwardDeadLetteredMessagesTo    types.String                 `tfsdk:"forward_dead_lettered_messages_to"`
+	RuntimeProperties                *queueRuntimePropertiesModel `tfsdk:"runtime_properties"`
+}
+
+type queueRuntimePropertiesModel struct {
+	ActiveMessageCount             types.Int64  `tfsdk:"active_message_count"`
+	DeadLetterMessageCount         types.Int64  `tfsdk:"dead_letter_message_count"`
+	ScheduledMessageCount          types.Int64  `tfsdk:"scheduled_message_count"`
+	TransferMessageCount           types.Int64  `tfsdk:"transfer_message_count"`
+	TransferDeadLetterMessageCount types.Int64  `tfsdk:"transfer_dead_letter_message_count"`
+	TotalMessageCount              types.Int64  `tfsdk:"total_message_count"`
+	SizeInBytes                    types.Int64  `tfsdk:"size_in_bytes"`
+	AccessedAt                     types.String `tfsdk:"accessed_at"`
+}
+
+func (d *queuesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_queues"
+}
+
+func (d *queuesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The Queues data source provides information about all queues in the namespace, " +
+			"for example to find queues, which are no longer backed by an endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return queues, whose name starts with this prefix.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return queues, whose name matches this regular expression.",
+			},
+			"include_runtime_properties": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to also read the message counts of the queues. Defaults to false.",
+			},
+			"queues": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The queues in the namespace.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the queue.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the queue.",
+						},
+						"enable_partitioning": schema.BoolAttribute{
+							Computed: true,
+						},
+						"max_size_in_megabytes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"max_message_size_in_kilobytes": schema.Int64Attribute{
+							Computed: true,
+						},
+						"max_delivery_count": schema.Int64Attribute{
+							Computed: true,
+						},
+						"lock_duration": schema.StringAttribute{
+							Computed:    true,
+							Description: "The lock duration in ISO 8601 format.",
+						},
+						"default_message_time_to_live": schema.StringAttribute{
+							Computed:    true,
+							Description: "The default message time to live in ISO 8601 format.",
+						},
+						"requires_session": schema.BoolAttribute{
+							Computed: true,
+						},
+						"dead_lettering_on_message_expiration": schema.BoolAttribute{
+							Computed: true,
+						},
+						"forward_to": schema.StringAttribute{
+							Computed:    true,
+							Description: "The entity the queue forwards messages to.",
+						},
+						"forward_dead_lettered_messages_to": schema.StringAttribute{
+							Computed:    true,
+							Description: "The entity the queue forwards dead-lettered messages to.",
+						},
+						"runtime_properties": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "The message counts of the queue. Only set when include_runtime_properties is true.",
+							Attributes: map[string]schema.Attribute{
+								"active_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"dead_letter_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"scheduled_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"transfer_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"transfer_dead_letter_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"total_message_count": schema.Int64Attribute{
+									Computed: true,
+								},
+								"size_in_bytes": schema.Int64Attribute{
+									Computed: true,
+								},
+								"accessed_at": schema.StringAttribute{
+									Computed:    true,
+									Description: "The time the queue was last accessed, in RFC 3339 format.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *queuesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state queuesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid regular expression",
+				"Could not compile name_regex: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	queues, err := client.GetQueues(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Queues",
+			"Could not list Queues, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	runtimeProperties := map[string]az.QueueRuntimeProperties{}
+	if state.IncludeRuntimeProperties.ValueBool() {
+		queuesRuntimeProperties, err := client.GetQueuesRuntimeProperties(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Queue runtime properties",
+				"Could not list Queue runtime properties, unexpected error: "+err.Error(),
+			)
+			return
+		}
+
+		for _, queue := range queuesRuntimeProperties {
+			runtimeProperties[queue.QueueName] = queue.QueueRuntimeProperties
+		}
+	}
+
+	state.Queues = []queueModel{}
+	for _, queue := range queues {
+		if !strings.HasPrefix(queue.QueueName, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(queue.QueueName) {
+			continue
+		}
+
+		model := queueModel{
+			Name:                             types.StringValue(queue.QueueName),
+			Status:                           types.StringNull(),
+			EnablePartitioning:               types.BoolPointerValue(queue.EnablePartitioning),
+			MaxSizeInMegabytes:               types.Int64Null(),
+			MaxMessageSizeInKilobytes:        types.Int64PointerValue(queue.MaxMessageSizeInKilobytes),
+			MaxDeliveryCount:                 types.Int64Null(),
+			LockDuration:                     types.StringPointerValue(queue.LockDuration),
+			DefaultMessageTimeToLive:         types.StringPointerValue(queue.DefaultMessageTimeToLive),
+			RequiresSession:                  types.BoolPointerValue(queue.RequiresSession),
+			DeadLetteringOnMessageExpiration: types.BoolPointerValue(queue.DeadLetteringOnMessageExpiration),
+			ForwardTo:                        types.StringPointerValue(queue.ForwardTo),
+			ForwardDeadLetteredMessagesTo:    types.StringPointerValue(queue.ForwardDeadLetteredMessagesTo),
+		}
+		if queue.Status != nil {
+			model.Status = types.StringValue(string(*queue.Status))
+		}
+		if queue.MaxSizeInMegabytes != nil {
+			model.MaxSizeInMegabytes = types.Int64Value(int64(*queue.MaxSizeInMegabytes))
+		}
+		if queue.MaxDeliveryCount != nil {
+			model.MaxDeliveryCount = types.Int64Value(int64(*queue.MaxDeliveryCount))
+		}
+
+		if queueRuntimeProperties, ok := runtimeProperties[queue.QueueName]; ok {
+			model.RuntimeProperties = &queueRuntimePropertiesModel{
+				ActiveMessageCount:             types.Int64Value(int64(queueRuntimeProperties.ActiveMessageCount)),
+				DeadLetterMessageCount:         types.Int64Value(int64(queueRuntimeProperties.DeadLetterMessageCount)),
+				ScheduledMessageCount:          types.Int64Value(int64(queueRuntimeProperties.ScheduledMessageCount)),
+				TransferMessageCount:           types.Int64Value(int64(queueRuntimeProperties.TransferMessageCount)),
+				TransferDeadLetterMessageCount: types.Int64Value(int64(queueRuntimeProperties.TransferDeadLetterMessageCount)),
+				TotalMessageCount:              types.Int64Value(queueRuntimeProperties.TotalMessageCount),
+				SizeInBytes:                    types.Int64Value(queueRuntimeProperties.SizeInBytes),
+				AccessedAt:                     types.StringValue(queueRuntimeProperties.AccessedAt.Format(time.RFC3339)),
+			}
+		}
+
+		state.Queues = append(state.Queues, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
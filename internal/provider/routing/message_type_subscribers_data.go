@@ -0,0 +1,222 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &messageTypeSubscribersDataSource{}
+	_ datasource.DataSourceWithConfigure = &messageTypeSubscribersDataSource{}
+)
+
+func NewMessageTypeSubscribersDataSource() datasource.DataSource {
+	return &messageTypeSubscribersDataSource{}
+}
+
+type messageTypeSubscribersDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *messageTypeSubscribersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type messageTypeSubscribersDataSourceModel struct {
+	Namespace        types.String                      `tfsdk:"namespace"`
+	MessageType      types.String                      `tfsdk:"message_type"`
+	TopicNames       []string                          `tfsdk:"topic_names"`
+	Subscribers      []messageTypeSubscriberModel      `tfsdk:"subscribers"`
+	UnevaluatedRules []messageTypeUnevaluatedRuleModel `tfsdk:"unevaluated_rules"`
+}
+
+type messageTypeSubscriberModel struct {
+	TopicName    types.String `tfsdk:"topic_name"`
+	EndpointName types.String `tfsdk:"endpoint_name"`
+	RuleName     types.String `tfsdk:"rule_name"`
+	Filter       types.String `tfsdk:"filter"`
+	FilterType   types.String `tfsdk:"filter_type"`
+	ForwardTo    types.String `tfsdk:"forward_to"`
+}
+
+type messageTypeUnevaluatedRuleModel struct {
+	TopicName    types.String `tfsdk:"topic_name"`
+	EndpointName types.String `tfsdk:"endpoint_name"`
+	RuleName     types.String `tfsdk:"rule_name"`
+	FilterType   types.String `tfsdk:"filter_type"`
+	Reason       types.String `tfsdk:"reason"`
+}
+
+func (d *messageTypeSubscribersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_message_type_subscribers"
+}
+
+func (d *messageTypeSubscribersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The Message Type Subscribers data source returns every endpoint subscription, whose rules would route a message type.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"message_type": schema.StringAttribute{
+				Required:    true,
+				Description: "The full name of the message type. Example: 'Dg.SalesOrder.V1.SalesOrderCreated'",
+			},
+			"topic_names": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The names of the topics, on which the message type is published.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"subscribers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The subscription rules, which route the message type.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"topic_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the topic of the subscription.",
+						},
+						"endpoint_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the endpoint subscription.",
+						},
+						"rule_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the rule, which routes the message type.",
+						},
+						"filter": schema.StringAttribute{
+							Computed:    true,
+							Description: "The filter of the rule.",
+						},
+						"filter_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The filter type of the rule.",
+						},
+						"forward_to": schema.StringAttribute{
+							Computed:    true,
+							Description: "The entity the subscription forwards messages to.",
+						},
+					},
+				},
+			},
+			"unevaluated_rules": schema.ListNestedAttribute{
+				Computed: true,
+				Description: "The rules that could not be evaluated locally, e.g. because they use unsupported SQL functions. " +
+					"Their subscriptions might route the message type as well.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"topic_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the topic of the subscription.",
+						},
+						"endpoint_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the endpoint subscription.",
+						},
+						"rule_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"filter_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The filter type of the rule.",
+						},
+						"reason": schema.StringAttribute{
+							Computed:    true,
+							Description: "The reason why the rule could not be evaluated.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *messageTypeSubscribersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state messageTypeSubscribersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	state.Subscribers = []messageTypeSubscriberModel{}
+	state.UnevaluatedRules = []messageTypeUnevaluatedRuleModel{}
+
+	for _, topicName := range state.TopicNames {
+		subscribers, unevaluatedRules, err := client.GetMessageTypeSubscribers(ctx, topicName, state.MessageType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Subscribers",
+				fmt.Sprintf("Could not get Subscribers of topic %s, unexpected error: %s", topicName, err.Error()),
+			)
+			return
+		}
+
+		for _, subscriber := range subscribers {
+			state.Subscribers = append(state.Subscribers, messageTypeSubscriberModel{
+				TopicName:    types.StringValue(subscriber.TopicName),
+				EndpointName: types.StringValue(subscriber.EndpointName),
+				RuleName:     types.StringValue(subscriber.Rule.Name),
+				Filter:       types.StringValue(subscriber.Rule.Filter),
+				FilterType:   types.StringValue(subscriber.Rule.FilterType),
+				ForwardTo:    types.StringPointerValue(subscriber.ForwardTo),
+			})
+		}
+
+		for _, rule := range unevaluatedRules {
+			state.UnevaluatedRules = append(state.UnevaluatedRules, messageTypeUnevaluatedRuleModel{
+				TopicName:    types.StringValue(rule.TopicName),
+				EndpointName: types.StringValue(rule.EndpointName),
+				RuleName:     types.StringValue(rule.RuleName),
+				FilterType:   types.StringValue(rule.FilterType),
+				Reason:       types.StringValue(rule.Reason),
+			})
+		}
+	}
+
+	if len(state.UnevaluatedRules) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Some rules could not be evaluated",
+			fmt.Sprintf("%d rules could not be evaluated locally, so it is unknown whether their endpoints subscribe to %s. "+
+				"Check `unevaluated_rules` for details.", len(state.UnevaluatedRules), state.MessageType.ValueString()),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
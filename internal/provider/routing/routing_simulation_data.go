@@ -0,0 +1,314 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-dg-servicebus/internal/provider/asb"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &routingSimulationDataSource{}
+	_ datasource.DataSourceWithConfigure = &routingSimulationDataSource{}
+)
+
+func NewRoutingSimulationDataSource() datasource.DataSource {
+	return &routingSimulationDataSource{}
+}
+
+type routingSimulationDataSource struct {
+	client *asb.AsbClientWrapper
+}
+
+func (d *routingSimulationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil { // If nil will be configured
+		return
+	}
+
+	client, ok := req.ProviderData.(*asb.AsbClientWrapper)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data source Configuration Type",
+			fmt.Sprintf("Expected *asb.AsbClientWrapper, got %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+type routingSimulationDataSourceModel struct {
+	Namespace         types.String                  `tfsdk:"namespace"`
+	TopicName         types.String                  `tfsdk:"topic_name"`
+	MessageProperties map[string]string             `tfsdk:"message_properties"`
+	SystemProperties  *routingSystemPropertiesModel `tfsdk:"system_properties"`
+	Receivers         []routingReceiverModel        `tfsdk:"receivers"`
+	UnevaluatedRules  []routingUnevaluatedRuleModel `tfsdk:"unevaluated_rules"`
+}
+
+type routingSystemPropertiesModel struct {
+	MessageID        types.String `tfsdk:"message_id"`
+	CorrelationID    types.String `tfsdk:"correlation_id"`
+	To               types.String `tfsdk:"to"`
+	ReplyTo          types.String `tfsdk:"reply_to"`
+	Subject          types.String `tfsdk:"subject"`
+	SessionID        types.String `tfsdk:"session_id"`
+	ReplyToSessionID types.String `tfsdk:"reply_to_session_id"`
+	ContentType      types.String `tfsdk:"content_type"`
+}
+
+type routingReceiverModel struct {
+	EndpointName types.String              `tfsdk:"endpoint_name"`
+	ForwardTo    types.String              `tfsdk:"forward_to"`
+	Status       types.String              `tfsdk:"status"`
+	MatchedRules []routingMatchedRuleModel `tfsdk:"matched_rules"`
+}
+
+type routingMatchedRuleModel struct {
+	RuleName   types.String `tfsdk:"rule_name"`
+	FilterType types.String `tfsdk:"filter_type"`
+}
+
+type routingUnevaluatedRuleModel struct {
+	EndpointName types.String `tfsdk:"endpoint_name"`
+	RuleName     types.String `tfsdk:"rule_name"`
+	FilterType   types.String `tfsdk:"filter_type"`
+	Reason       types.String `tfsdk:"reason"`
+}
+
+func (d *routingSimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_routing_simulation"
+}
+
+func (d *routingSimulationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The Routing Simulation data source evaluates the rules of every subscription on a topic against a sample message " +
+			"and returns the endpoints that would receive it. The filters are evaluated locally, no message is sent.",
+
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace to read from, instead of the namespace of the provider. Accepts the namespace name, the hostname or an sb:// url.",
+			},
+			"topic_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the topic, to which the message would be published.",
+			},
+			"message_properties": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The application properties of the sample message, e.g. `NServiceBus.EnclosedMessageTypes` or `Dg.MessageTypeFullName`.",
+			},
+			"system_properties": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "The system properties of the sample message.",
+				Attributes: map[string]schema.Attribute{
+					"message_id": schema.StringAttribute{
+						Optional: true,
+					},
+					"correlation_id": schema.StringAttribute{
+						Optional: true,
+					},
+					"to": schema.StringAttribute{
+						Optional: true,
+					},
+					"reply_to": schema.StringAttribute{
+						Optional: true,
+					},
+					"subject": schema.StringAttribute{
+						Optional:    true,
+						Description: "The subject of the message, which is called `sys.Label` in SQL filters.",
+					},
+					"session_id": schema.StringAttribute{
+						Optional: true,
+					},
+					"reply_to_session_id": schema.StringAttribute{
+						Optional: true,
+					},
+					"content_type": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+			"receivers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The endpoints that would receive the message.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the endpoint subscription.",
+						},
+						"forward_to": schema.StringAttribute{
+							Computed:    true,
+							Description: "The entity the subscription forwards messages to.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the subscription.",
+						},
+						"matched_rules": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The rules that matched the message.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"rule_name": schema.StringAttribute{
+										Computed:    true,
+										Description: "The name of the rule.",
+									},
+									"filter_type": schema.StringAttribute{
+										Computed:    true,
+										Description: "The filter type of the rule.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"unevaluated_rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The rules that could not be evaluated locally, e.g. because they use unsupported SQL functions.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the endpoint subscription.",
+						},
+						"rule_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"filter_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The filter type of the rule.",
+						},
+						"reason": schema.StringAttribute{
+							Computed:    true,
+							Description: "The reason why the rule could not be evaluated.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (model routingSimulationDataSourceModel) ToAsbMessage() asb.AsbMessage {
+	message := asb.AsbMessage{
+		ApplicationProperties: model.MessageProperties,
+	}
+
+	if model.SystemProperties != nil {
+		message.SystemProperties = asb.AsbMessageSystemProperties{
+			MessageID:        model.SystemProperties.MessageID.ValueStringPointer(),
+			CorrelationID:    model.SystemProperties.CorrelationID.ValueStringPointer(),
+			To:               model.SystemProperties.To.ValueStringPointer(),
+			ReplyTo:          model.SystemProperties.ReplyTo.ValueStringPointer(),
+			Subject:          model.SystemProperties.Subject.ValueStringPointer(),
+			SessionID:        model.SystemProperties.SessionID.ValueStringPointer(),
+			ReplyToSessionID: model.SystemProperties.ReplyToSessionID.ValueStringPointer(),
+			ContentType:      model.SystemProperties.ContentType.ValueStringPointer(),
+		}
+	}
+
+	return message
+}
+
+func (d *routingSimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state routingSimulationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client.ForNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Invalid namespace",
+			"Could not create a client for the namespace: "+err.Error(),
+		)
+		return
+	}
+
+	topicName := state.TopicName.ValueString()
+	message := state.ToAsbMessage()
+
+	subscriptions, err := client.GetTopicSubscriptions(ctx, topicName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error getting Subscriptions",
+			fmt.Sprintf("Could not list Subscriptions of topic %s, unexpected error: %s", topicName, err.Error()),
+		)
+		return
+	}
+
+	state.Receivers = []routingReceiverModel{}
+	state.UnevaluatedRules = []routingUnevaluatedRuleModel{}
+
+	for _, subscription := range subscriptions {
+		rules, err := client.GetSubscriptionRules(ctx, topicName, subscription.SubscriptionName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error getting Rules",
+				fmt.Sprintf("Could not list Rules of subscription %s, unexpected error: %s", subscription.SubscriptionName, err.Error()),
+			)
+			return
+		}
+
+		matchedRules := []routingMatchedRuleModel{}
+		for _, rule := range rules {
+			filterType := asb.GetRuleFilterType(rule.Filter)
+
+			matches, err := asb.EvaluateRuleFilter(rule.Filter, message)
+			if err != nil {
+				tflog.Info(ctx, fmt.Sprintf("Rule %s of subscription %s could not be evaluated: %s", rule.Name, subscription.SubscriptionName, err.Error()))
+				state.UnevaluatedRules = append(state.UnevaluatedRules, routingUnevaluatedRuleModel{
+					EndpointName: types.StringValue(subscription.SubscriptionName),
+					RuleName:     types.StringValue(rule.Name),
+					FilterType:   types.StringValue(filterType),
+					Reason:       types.StringValue(err.Error()),
+				})
+				continue
+			}
+
+			if matches {
+				matchedRules = append(matchedRules, routingMatchedRuleModel{
+					RuleName:   types.StringValue(rule.Name),
+					FilterType: types.StringValue(filterType),
+				})
+			}
+		}
+
+		if len(matchedRules) == 0 {
+			continue
+		}
+
+		receiver := routingReceiverModel{
+			EndpointName: types.StringValue(subscription.SubscriptionName),
+			ForwardTo:    types.StringPointerValue(subscription.ForwardTo),
+			Status:       types.StringNull(),
+			MatchedRules: matchedRules,
+		}
+		if subscription.Status != nil {
+			receiver.Status = types.StringValue(string(*subscription.Status))
+		}
+
+		state.Receivers = append(state.Receivers, receiver)
+	}
+
+	if len(state.UnevaluatedRules) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Some rules could not be evaluated",
+			fmt.Sprintf("%d rules on topic %s could not be evaluated locally. Check `unevaluated_rules` for details.", len(state.UnevaluatedRules), topicName),
+		)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}